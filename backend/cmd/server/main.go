@@ -6,8 +6,15 @@ import (
 	"cv-gen/backend/internal/db"
 	"cv-gen/backend/internal/handlers"
 	"cv-gen/backend/internal/routes"
+	adminSvc "cv-gen/backend/internal/services/admin"
 	"cv-gen/backend/internal/services/ai"
+	applicationsSvc "cv-gen/backend/internal/services/applications"
 	coverletterSvc "cv-gen/backend/internal/services/coverletter"
+	creditsSvc "cv-gen/backend/internal/services/credits"
+	"cv-gen/backend/internal/services/jobpage"
+	jobsSvc "cv-gen/backend/internal/services/jobs"
+	paymentsSvc "cv-gen/backend/internal/services/payments"
+	shareSvc "cv-gen/backend/internal/services/share"
 	"log"
 	"net/http"
 	"os"
@@ -48,8 +55,14 @@ func main() {
 		queries = db.New(pool.Pool)
 	}
 
+	// Initialize credits service
+	var creditsService *creditsSvc.Service
+	if queries != nil {
+		creditsService = creditsSvc.New(pool, queries)
+	}
+
 	// Create handler with dependencies
-	h := handlers.New(queries)
+	h := handlers.New(pool, queries, creditsService)
 
 	// Initialize cover letter handler
 	var coverLetterHandler *handlers.CoverLetterHandler
@@ -59,24 +72,70 @@ func main() {
 		log.Println("Cover letter service initialized successfully")
 	}
 
+	// Initialize saved jobs
+	var jobHandler *handlers.JobHandler
+	if queries != nil {
+		jobService := jobsSvc.New(pool, queries)
+		jobHandler = handlers.NewJobHandler(jobService)
+		log.Println("Job service initialized successfully")
+	}
+
+	// Job import from URLs needs neither the database nor an AI provider
+	jobPageHandler := handlers.NewJobPageHandler(jobpage.NewFetcher(nil))
+
+	// Initialize application tracking
+	var applicationHandler *handlers.ApplicationHandler
+	if queries != nil {
+		applicationService := applicationsSvc.New(pool, queries)
+		applicationHandler = handlers.NewApplicationHandler(applicationService)
+		log.Println("Application service initialized successfully")
+	}
+
 	// Initialize AI service
 	var aiHandler *handlers.AIHandler
-	if cfg.GeminiAPIKey != "" && queries != nil {
-		aiService, err := ai.New(cfg.GeminiAPIKey, queries)
+	if queries != nil {
+		aiService, err := ai.New(cfg, queries, creditsService, h.CVService)
 		if err != nil {
-			log.Printf("WARNING: Failed to initialize AI service: %v", err)
+			log.Printf("WARNING: Failed to initialize AI service (provider %q), AI features will be disabled: %v", cfg.AIProvider, err)
 		} else {
 			aiHandler = handlers.NewAIHandler(aiService)
-			log.Println("AI service initialized successfully")
+			log.Printf("AI service initialized successfully (provider %q)", cfg.AIProvider)
 			defer aiService.Close()
 		}
 	} else {
-		if cfg.GeminiAPIKey == "" {
-			log.Println("WARNING: GEMINI_API_KEY not set, AI features will be disabled")
-		}
-		if queries == nil {
-			log.Println("WARNING: Database not connected, AI features will be disabled")
+		log.Println("WARNING: Database not connected, AI features will be disabled")
+	}
+
+	// Initialize payment webhooks
+	var paymentsHandler *handlers.PaymentsHandler
+	if queries != nil && cfg.PaymentWebhookSecret != "" {
+		packages, err := paymentsSvc.ParsePackages(cfg.CreditPackages)
+		if err != nil {
+			log.Printf("WARNING: Invalid CREDIT_PACKAGES, payment webhooks will be disabled: %v", err)
+		} else {
+			paymentsStore := paymentsSvc.NewStore(pool, queries, creditsService)
+			paymentsService := paymentsSvc.New(cfg.PaymentWebhookSecret, cfg.PaymentWebhookTolerance, packages, paymentsStore)
+			paymentsHandler = handlers.NewPaymentsHandler(paymentsService)
+			log.Println("Payment webhooks initialized successfully")
 		}
+	} else {
+		log.Println("WARNING: PAYMENT_WEBHOOK_SECRET not set or database not connected, payment webhooks will be disabled")
+	}
+
+	// Initialize admin API
+	var adminHandler *handlers.AdminHandler
+	if queries != nil {
+		adminService := adminSvc.New(queries, creditsService)
+		adminHandler = handlers.NewAdminHandler(adminService, cfg.AdminUserIDs)
+		log.Printf("Admin API initialized successfully (%d allow-listed users)", len(cfg.AdminUserIDs))
+	}
+
+	// Initialize share links
+	var shareHandler *handlers.ShareHandler
+	if queries != nil {
+		shareService := shareSvc.New(queries)
+		shareHandler = handlers.NewShareHandler(shareService, cfg.PublicBaseURL)
+		log.Println("Share links initialized successfully")
 	}
 
 	e := echo.New()
@@ -87,11 +146,11 @@ func main() {
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:3000", "http://localhost:5173", "http://cv.aidityas.me", "https://cv.aidityas.me"},
 		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodPatch},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, handlers.SharePasswordHeader},
 	}))
 
 	// Register routes
-	routes.Register(e, h, aiHandler, coverLetterHandler)
+	routes.Register(e, h, aiHandler, coverLetterHandler, paymentsHandler, adminHandler, shareHandler, jobHandler, jobPageHandler, applicationHandler)
 
 	// Get port from configuration
 	port := cfg.BackendPort
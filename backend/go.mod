@@ -6,6 +6,8 @@ require (
 	github.com/clerk/clerk-sdk-go/v2 v2.5.1
 	github.com/jackc/pgx/v5 v5.8.0
 	github.com/labstack/echo/v4 v4.15.0
+	golang.org/x/crypto v0.46.0
+	golang.org/x/net v0.48.0
 	google.golang.org/genai v1.40.0
 )
 
@@ -28,8 +30,6 @@ require (
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	go.opencensus.io v0.24.0 // indirect
-	golang.org/x/crypto v0.46.0 // indirect
-	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
@@ -0,0 +1,152 @@
+// Package ats scores how well a CV covers the keywords of a job description,
+// the way applicant tracking systems screen CVs. Unlike the match score the
+// AI gives, the score is deterministic: the same CV and job description
+// always score the same.
+package ats
+
+import (
+	"sort"
+
+	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/terms"
+)
+
+const (
+	// maxTermWeight caps how much a term repeated throughout a job description counts
+	maxTermWeight = 3
+	// vocabularyFactor is how much more technical terms weigh than ordinary words
+	vocabularyFactor = 2
+)
+
+// Result is the keyword match of a CV against a job description
+type Result struct {
+	// Score is the weighted share of job description keywords the CV covers, 0-100
+	Score int `json:"score"`
+	// Coverage is the unweighted share of job description keywords the CV covers, 0-100
+	Coverage int `json:"coverage"`
+	// Matched and Missing are the keywords as the job description spells them,
+	// most important first
+	Matched []string `json:"matched"`
+	Missing []string `json:"missing"`
+}
+
+// keyword is a term of a job description with how much it counts
+type keyword struct {
+	key     string
+	surface string
+	weight  int
+}
+
+// Score matches the skills, highlights and summaries of a CV against the
+// keywords of a job description. A keyword counts more the more often the job
+// description repeats it, and more when it is a technical term. A job
+// description without keywords scores 0.
+func Score(jobDescription string, resume *models.JSONResume) *Result {
+	keywords := jobKeywords(jobDescription)
+	result := &Result{Matched: []string{}, Missing: []string{}}
+	if len(keywords) == 0 {
+		return result
+	}
+
+	covered := map[string]bool{}
+	for _, text := range resumeTexts(resume) {
+		for _, t := range terms.Parse(text) {
+			covered[t.Key] = true
+		}
+	}
+
+	total, matched := 0, 0
+	for _, k := range keywords {
+		total += k.weight
+		if covered[k.key] {
+			matched += k.weight
+			result.Matched = append(result.Matched, k.surface)
+		} else {
+			result.Missing = append(result.Missing, k.surface)
+		}
+	}
+
+	result.Score = percent(matched, total)
+	result.Coverage = percent(len(result.Matched), len(keywords))
+	return result
+}
+
+// jobKeywords returns the distinct terms of a job description, heaviest first
+// and alphabetically among equals
+func jobKeywords(jobDescription string) []keyword {
+	var keywords []keyword
+	index := map[string]int{}
+	for _, t := range terms.Parse(jobDescription) {
+		if i, ok := index[t.Key]; ok {
+			keywords[i].weight++
+			continue
+		}
+		index[t.Key] = len(keywords)
+		keywords = append(keywords, keyword{key: t.Key, surface: t.Surface, weight: 1})
+	}
+
+	for i := range keywords {
+		k := &keywords[i]
+		k.weight = min(k.weight, maxTermWeight)
+		if terms.IsTechnical(k.key) {
+			k.weight *= vocabularyFactor
+		}
+	}
+
+	sort.SliceStable(keywords, func(i, j int) bool {
+		if keywords[i].weight != keywords[j].weight {
+			return keywords[i].weight > keywords[j].weight
+		}
+		return keywords[i].key < keywords[j].key
+	})
+	return keywords
+}
+
+// resumeTexts returns the parts of a CV that describe skills and experience.
+// Names of employers, contact details and dates are left out.
+func resumeTexts(resume *models.JSONResume) []string {
+	if resume == nil {
+		return nil
+	}
+
+	var parts []string
+	add := func(texts ...string) {
+		parts = append(parts, texts...)
+	}
+
+	if resume.Basics != nil {
+		add(resume.Basics.Label, resume.Basics.Summary)
+	}
+	for _, w := range resume.Work {
+		add(w.Position, w.Summary)
+		add(w.Highlights...)
+	}
+	for _, v := range resume.Volunteer {
+		add(v.Position, v.Summary)
+		add(v.Highlights...)
+	}
+	for _, p := range resume.Projects {
+		add(p.Name, p.Description)
+		add(p.Highlights...)
+		add(p.Keywords...)
+	}
+	for _, s := range resume.Skills {
+		add(s.Name)
+		add(s.Keywords...)
+	}
+	for _, e := range resume.Education {
+		add(e.Area, e.StudyType)
+		add(e.Courses...)
+	}
+	for _, c := range resume.Certificates {
+		add(c.Name)
+	}
+	return parts
+}
+
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return (part*100 + total/2) / total
+}
@@ -0,0 +1,53 @@
+package ats
+
+import (
+	"reflect"
+	"testing"
+
+	"cv-gen/backend/internal/models"
+)
+
+func TestScore(t *testing.T) {
+	jobDescription := `Senior Golang Engineer
+We are looking for an engineer with 5+ years of experience building scalable
+services in Golang. Experience with Postgres and K8s is required. Machine
+learning is a plus. You will mentor engineers.`
+
+	resume := &models.JSONResume{
+		Basics: &models.Basics{
+			Name:    "Ada",
+			Email:   "mentor@example.com",
+			Summary: "Senior engineer who builds services that scale.",
+		},
+		Work: []models.Work{
+			{Name: "Kubernetes Inc", Highlights: []string{"Moved billing to PostgreSQL"}},
+		},
+		Skills: []models.Skill{
+			{Name: "Languages", Keywords: []string{"Go"}},
+		},
+	}
+
+	got := Score(jobDescription, resume)
+
+	want := &Result{
+		Score:    72,
+		Coverage: 70,
+		Matched:  []string{"golang", "engineer", "postgres", "building", "scalable", "senior", "services"},
+		Missing:  []string{"k8s", "machine learning", "mentor"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Score() = %+v, want %+v", got, want)
+	}
+
+	if again := Score(jobDescription, resume); !reflect.DeepEqual(again, got) {
+		t.Errorf("Score() is not reproducible: %+v then %+v", got, again)
+	}
+}
+
+func TestScoreWithoutKeywords(t *testing.T) {
+	got := Score("We are looking for 2 years.", &models.JSONResume{})
+	want := &Result{Matched: []string{}, Missing: []string{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Score() = %+v, want %+v", got, want)
+	}
+}
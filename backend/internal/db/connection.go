@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -12,6 +13,12 @@ type Pool struct {
 	*pgxpool.Pool
 }
 
+// TxBeginner starts database transactions (satisfied by *pgxpool.Pool). Services
+// run their queries in a transaction with Queries.WithTx.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // NewPool creates a new database connection pool
 func NewPool(ctx context.Context, databaseURL string) (*Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
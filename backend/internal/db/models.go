@@ -8,6 +8,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Application struct {
+	ID            pgtype.UUID        `json:"id"`
+	UserID        string             `json:"user_id"`
+	CvID          pgtype.UUID        `json:"cv_id"`
+	CoverLetterID pgtype.UUID        `json:"cover_letter_id"`
+	JobID         pgtype.UUID        `json:"job_id"`
+	JobTitle      pgtype.Text        `json:"job_title"`
+	CompanyName   pgtype.Text        `json:"company_name"`
+	JobUrl        pgtype.Text        `json:"job_url"`
+	Status        string             `json:"status"`
+	Notes         string             `json:"notes"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ApplicationStatusChange struct {
+	ID            pgtype.UUID        `json:"id"`
+	ApplicationID pgtype.UUID        `json:"application_id"`
+	FromStatus    pgtype.Text        `json:"from_status"`
+	ToStatus      string             `json:"to_status"`
+	Note          string             `json:"note"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+}
+
 type CoverLetter struct {
 	ID          pgtype.UUID        `json:"id"`
 	UserID      string             `json:"user_id"`
@@ -17,22 +41,84 @@ type CoverLetter struct {
 	CompanyName pgtype.Text        `json:"company_name"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	JobID       pgtype.UUID        `json:"job_id"`
+}
+
+type CreditTransaction struct {
+	ID                    pgtype.UUID        `json:"id"`
+	UserID                string             `json:"user_id"`
+	Amount                int32              `json:"amount"`
+	Reason                string             `json:"reason"`
+	CvID                  pgtype.UUID        `json:"cv_id"`
+	CoverLetterID         pgtype.UUID        `json:"cover_letter_id"`
+	BalanceAfter          int32              `json:"balance_after"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	RefundedTransactionID pgtype.UUID        `json:"refunded_transaction_id"`
+}
+
+type CvRevision struct {
+	ID           pgtype.UUID        `json:"id"`
+	CvID         pgtype.UUID        `json:"cv_id"`
+	UserID       string             `json:"user_id"`
+	Revision     int32              `json:"revision"`
+	Name         string             `json:"name"`
+	CvData       []byte             `json:"cv_data"`
+	TemplateID   pgtype.Text        `json:"template_id"`
+	Source       string             `json:"source"`
+	RestoredFrom pgtype.Int4        `json:"restored_from"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type CvShare struct {
+	ID           pgtype.UUID        `json:"id"`
+	CvID         pgtype.UUID        `json:"cv_id"`
+	UserID       string             `json:"user_id"`
+	Token        string             `json:"token"`
+	PasswordHash pgtype.Text        `json:"password_hash"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
+	ViewCount    int32              `json:"view_count"`
+	LastViewedAt pgtype.Timestamptz `json:"last_viewed_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
 
 type GeneratedCv struct {
-	ID             pgtype.UUID        `json:"id"`
-	UserID         string             `json:"user_id"`
-	Name           string             `json:"name"`
-	JobUrl         pgtype.Text        `json:"job_url"`
-	JobTitle       pgtype.Text        `json:"job_title"`
-	CompanyName    pgtype.Text        `json:"company_name"`
-	JobDescription pgtype.Text        `json:"job_description"`
-	CvData         []byte             `json:"cv_data"`
-	MatchScore     pgtype.Int4        `json:"match_score"`
-	AiSuggestions  []byte             `json:"ai_suggestions"`
-	TemplateID     pgtype.Text        `json:"template_id"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	ID              pgtype.UUID        `json:"id"`
+	UserID          string             `json:"user_id"`
+	Name            string             `json:"name"`
+	JobUrl          pgtype.Text        `json:"job_url"`
+	JobTitle        pgtype.Text        `json:"job_title"`
+	CompanyName     pgtype.Text        `json:"company_name"`
+	JobDescription  pgtype.Text        `json:"job_description"`
+	CvData          []byte             `json:"cv_data"`
+	MatchScore      pgtype.Int4        `json:"match_score"`
+	AiSuggestions   []byte             `json:"ai_suggestions"`
+	TemplateID      pgtype.Text        `json:"template_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	JobID           pgtype.UUID        `json:"job_id"`
+	JobRequirements []byte             `json:"job_requirements"`
+	KeywordScore    pgtype.Int4        `json:"keyword_score"`
+}
+
+type Job struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      string             `json:"user_id"`
+	Title       pgtype.Text        `json:"title"`
+	CompanyName pgtype.Text        `json:"company_name"`
+	JobUrl      pgtype.Text        `json:"job_url"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type JobAnalysis struct {
+	ID              pgtype.UUID        `json:"id"`
+	JobID           pgtype.UUID        `json:"job_id"`
+	ProfileVersion  int32              `json:"profile_version"`
+	Analysis        []byte             `json:"analysis"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	DescriptionHash string             `json:"description_hash"`
 }
 
 type MasterProfile struct {
@@ -43,6 +129,25 @@ type MasterProfile struct {
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
+type MasterProfileVersion struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          string             `json:"user_id"`
+	Version         int32              `json:"version"`
+	ResumeData      []byte             `json:"resume_data"`
+	ChangedSections []string           `json:"changed_sections"`
+	RestoredFrom    pgtype.Int4        `json:"restored_from"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type PaymentEvent struct {
+	EventID     string             `json:"event_id"`
+	EventType   string             `json:"event_type"`
+	UserID      string             `json:"user_id"`
+	Package     string             `json:"package"`
+	Credits     int32              `json:"credits"`
+	ProcessedAt pgtype.Timestamptz `json:"processed_at"`
+}
+
 type UserCredit struct {
 	ID                   pgtype.UUID        `json:"id"`
 	UserID               string             `json:"user_id"`
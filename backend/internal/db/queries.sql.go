@@ -51,26 +51,127 @@ func (q *Queries) CountCVsByUser(ctx context.Context, userID string) (int64, err
 	return count, err
 }
 
+const countCreditTransactionsByUser = `-- name: CountCreditTransactionsByUser :one
+SELECT COUNT(*) FROM credit_transactions WHERE user_id = $1
+`
+
+func (q *Queries) CountCreditTransactionsByUser(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countCreditTransactionsByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createApplication = `-- name: CreateApplication :one
+
+INSERT INTO applications (
+    user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at
+`
+
+type CreateApplicationParams struct {
+	UserID        string      `json:"user_id"`
+	CvID          pgtype.UUID `json:"cv_id"`
+	CoverLetterID pgtype.UUID `json:"cover_letter_id"`
+	JobID         pgtype.UUID `json:"job_id"`
+	JobTitle      pgtype.Text `json:"job_title"`
+	CompanyName   pgtype.Text `json:"company_name"`
+	JobUrl        pgtype.Text `json:"job_url"`
+	Status        string      `json:"status"`
+	Notes         string      `json:"notes"`
+}
+
+// ===================
+// Applications
+// ===================
+func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationParams) (Application, error) {
+	row := q.db.QueryRow(ctx, createApplication,
+		arg.UserID,
+		arg.CvID,
+		arg.CoverLetterID,
+		arg.JobID,
+		arg.JobTitle,
+		arg.CompanyName,
+		arg.JobUrl,
+		arg.Status,
+		arg.Notes,
+	)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createApplicationStatusChange = `-- name: CreateApplicationStatusChange :one
+INSERT INTO application_status_changes (application_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING id, application_id, from_status, to_status, note, changed_at
+`
+
+type CreateApplicationStatusChangeParams struct {
+	ApplicationID pgtype.UUID `json:"application_id"`
+	FromStatus    pgtype.Text `json:"from_status"`
+	ToStatus      string      `json:"to_status"`
+	Note          string      `json:"note"`
+}
+
+func (q *Queries) CreateApplicationStatusChange(ctx context.Context, arg CreateApplicationStatusChangeParams) (ApplicationStatusChange, error) {
+	row := q.db.QueryRow(ctx, createApplicationStatusChange,
+		arg.ApplicationID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+	)
+	var i ApplicationStatusChange
+	err := row.Scan(
+		&i.ID,
+		&i.ApplicationID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Note,
+		&i.ChangedAt,
+	)
+	return i, err
+}
+
 const createCV = `-- name: CreateCV :one
 INSERT INTO generated_cvs (
     user_id, name, job_url, job_title, company_name, 
-    job_description, cv_data, match_score, ai_suggestions, template_id
+    job_description, cv_data, match_score, ai_suggestions, template_id, job_id, job_requirements,
+    keyword_score
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score
 `
 
 type CreateCVParams struct {
-	UserID         string      `json:"user_id"`
-	Name           string      `json:"name"`
-	JobUrl         pgtype.Text `json:"job_url"`
-	JobTitle       pgtype.Text `json:"job_title"`
-	CompanyName    pgtype.Text `json:"company_name"`
-	JobDescription pgtype.Text `json:"job_description"`
-	CvData         []byte      `json:"cv_data"`
-	MatchScore     pgtype.Int4 `json:"match_score"`
-	AiSuggestions  []byte      `json:"ai_suggestions"`
-	TemplateID     pgtype.Text `json:"template_id"`
+	UserID          string      `json:"user_id"`
+	Name            string      `json:"name"`
+	JobUrl          pgtype.Text `json:"job_url"`
+	JobTitle        pgtype.Text `json:"job_title"`
+	CompanyName     pgtype.Text `json:"company_name"`
+	JobDescription  pgtype.Text `json:"job_description"`
+	CvData          []byte      `json:"cv_data"`
+	MatchScore      pgtype.Int4 `json:"match_score"`
+	AiSuggestions   []byte      `json:"ai_suggestions"`
+	TemplateID      pgtype.Text `json:"template_id"`
+	JobID           pgtype.UUID `json:"job_id"`
+	JobRequirements []byte      `json:"job_requirements"`
+	KeywordScore    pgtype.Int4 `json:"keyword_score"`
 }
 
 func (q *Queries) CreateCV(ctx context.Context, arg CreateCVParams) (GeneratedCv, error) {
@@ -85,6 +186,9 @@ func (q *Queries) CreateCV(ctx context.Context, arg CreateCVParams) (GeneratedCv
 		arg.MatchScore,
 		arg.AiSuggestions,
 		arg.TemplateID,
+		arg.JobID,
+		arg.JobRequirements,
+		arg.KeywordScore,
 	)
 	var i GeneratedCv
 	err := row.Scan(
@@ -101,14 +205,104 @@ func (q *Queries) CreateCV(ctx context.Context, arg CreateCVParams) (GeneratedCv
 		&i.TemplateID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
 	)
 	return i, err
 }
 
-const createCoverLetter = `-- name: CreateCoverLetter :one
-INSERT INTO cover_letters (user_id, cv_id, content, job_title, company_name)
+const createCVRevision = `-- name: CreateCVRevision :one
+
+INSERT INTO cv_revisions (cv_id, user_id, revision, name, cv_data, template_id, source, restored_from)
+SELECT g.id, g.user_id,
+       COALESCE((SELECT MAX(r.revision) FROM cv_revisions r WHERE r.cv_id = g.id), 0) + 1,
+       g.name, g.cv_data, g.template_id, $3::VARCHAR, $4::INTEGER
+FROM generated_cvs g
+WHERE g.id = $1 AND g.user_id = $2
+RETURNING id, cv_id, user_id, revision, name, cv_data, template_id, source, restored_from, created_at
+`
+
+type CreateCVRevisionParams struct {
+	CvID         pgtype.UUID `json:"cv_id"`
+	UserID       string      `json:"user_id"`
+	Source       string      `json:"source"`
+	RestoredFrom pgtype.Int4 `json:"restored_from"`
+}
+
+// ===================
+// CV Revisions
+// ===================
+// Snapshots the current name, content and template of a CV as its next revision
+func (q *Queries) CreateCVRevision(ctx context.Context, arg CreateCVRevisionParams) (CvRevision, error) {
+	row := q.db.QueryRow(ctx, createCVRevision,
+		arg.CvID,
+		arg.UserID,
+		arg.Source,
+		arg.RestoredFrom,
+	)
+	var i CvRevision
+	err := row.Scan(
+		&i.ID,
+		&i.CvID,
+		&i.UserID,
+		&i.Revision,
+		&i.Name,
+		&i.CvData,
+		&i.TemplateID,
+		&i.Source,
+		&i.RestoredFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCVShare = `-- name: CreateCVShare :one
+
+INSERT INTO cv_shares (cv_id, user_id, token, password_hash, expires_at)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, cv_id, content, job_title, company_name, created_at, updated_at
+RETURNING id, cv_id, user_id, token, password_hash, expires_at, revoked_at, view_count, last_viewed_at, created_at
+`
+
+type CreateCVShareParams struct {
+	CvID         pgtype.UUID        `json:"cv_id"`
+	UserID       string             `json:"user_id"`
+	Token        string             `json:"token"`
+	PasswordHash pgtype.Text        `json:"password_hash"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+}
+
+// ===================
+// CV Shares
+// ===================
+func (q *Queries) CreateCVShare(ctx context.Context, arg CreateCVShareParams) (CvShare, error) {
+	row := q.db.QueryRow(ctx, createCVShare,
+		arg.CvID,
+		arg.UserID,
+		arg.Token,
+		arg.PasswordHash,
+		arg.ExpiresAt,
+	)
+	var i CvShare
+	err := row.Scan(
+		&i.ID,
+		&i.CvID,
+		&i.UserID,
+		&i.Token,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ViewCount,
+		&i.LastViewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCoverLetter = `-- name: CreateCoverLetter :one
+INSERT INTO cover_letters (user_id, cv_id, content, job_title, company_name, job_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id
 `
 
 type CreateCoverLetterParams struct {
@@ -117,6 +311,7 @@ type CreateCoverLetterParams struct {
 	Content     string      `json:"content"`
 	JobTitle    pgtype.Text `json:"job_title"`
 	CompanyName pgtype.Text `json:"company_name"`
+	JobID       pgtype.UUID `json:"job_id"`
 }
 
 func (q *Queries) CreateCoverLetter(ctx context.Context, arg CreateCoverLetterParams) (CoverLetter, error) {
@@ -126,6 +321,7 @@ func (q *Queries) CreateCoverLetter(ctx context.Context, arg CreateCoverLetterPa
 		arg.Content,
 		arg.JobTitle,
 		arg.CompanyName,
+		arg.JobID,
 	)
 	var i CoverLetter
 	err := row.Scan(
@@ -137,6 +333,92 @@ func (q *Queries) CreateCoverLetter(ctx context.Context, arg CreateCoverLetterPa
 		&i.CompanyName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+	)
+	return i, err
+}
+
+const createCreditTransaction = `-- name: CreateCreditTransaction :one
+
+INSERT INTO credit_transactions (user_id, amount, reason, cv_id, cover_letter_id, balance_after, refunded_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, amount, reason, cv_id, cover_letter_id, balance_after, created_at, refunded_transaction_id
+`
+
+type CreateCreditTransactionParams struct {
+	UserID                string      `json:"user_id"`
+	Amount                int32       `json:"amount"`
+	Reason                string      `json:"reason"`
+	CvID                  pgtype.UUID `json:"cv_id"`
+	CoverLetterID         pgtype.UUID `json:"cover_letter_id"`
+	BalanceAfter          int32       `json:"balance_after"`
+	RefundedTransactionID pgtype.UUID `json:"refunded_transaction_id"`
+}
+
+// ===================
+// Credit Transactions
+// ===================
+func (q *Queries) CreateCreditTransaction(ctx context.Context, arg CreateCreditTransactionParams) (CreditTransaction, error) {
+	row := q.db.QueryRow(ctx, createCreditTransaction,
+		arg.UserID,
+		arg.Amount,
+		arg.Reason,
+		arg.CvID,
+		arg.CoverLetterID,
+		arg.BalanceAfter,
+		arg.RefundedTransactionID,
+	)
+	var i CreditTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.Reason,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.BalanceAfter,
+		&i.CreatedAt,
+		&i.RefundedTransactionID,
+	)
+	return i, err
+}
+
+const createJob = `-- name: CreateJob :one
+
+INSERT INTO jobs (user_id, title, company_name, job_url, description)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, title, company_name, job_url, description, created_at, updated_at
+`
+
+type CreateJobParams struct {
+	UserID      string      `json:"user_id"`
+	Title       pgtype.Text `json:"title"`
+	CompanyName pgtype.Text `json:"company_name"`
+	JobUrl      pgtype.Text `json:"job_url"`
+	Description string      `json:"description"`
+}
+
+// ===================
+// Jobs
+// ===================
+func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, createJob,
+		arg.UserID,
+		arg.Title,
+		arg.CompanyName,
+		arg.JobUrl,
+		arg.Description,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -165,6 +447,80 @@ func (q *Queries) CreateMasterProfile(ctx context.Context, arg CreateMasterProfi
 	return i, err
 }
 
+const createMasterProfileVersion = `-- name: CreateMasterProfileVersion :one
+
+INSERT INTO master_profile_versions (user_id, version, resume_data, changed_sections, restored_from)
+VALUES (
+    $1,
+    COALESCE((SELECT MAX(v.version) FROM master_profile_versions v WHERE v.user_id = $1), 0) + 1,
+    $2, $3, $4
+)
+RETURNING id, user_id, version, resume_data, changed_sections, restored_from, created_at
+`
+
+type CreateMasterProfileVersionParams struct {
+	UserID          string      `json:"user_id"`
+	ResumeData      []byte      `json:"resume_data"`
+	ChangedSections []string    `json:"changed_sections"`
+	RestoredFrom    pgtype.Int4 `json:"restored_from"`
+}
+
+// ===================
+// Master Profile Versions
+// ===================
+func (q *Queries) CreateMasterProfileVersion(ctx context.Context, arg CreateMasterProfileVersionParams) (MasterProfileVersion, error) {
+	row := q.db.QueryRow(ctx, createMasterProfileVersion,
+		arg.UserID,
+		arg.ResumeData,
+		arg.ChangedSections,
+		arg.RestoredFrom,
+	)
+	var i MasterProfileVersion
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Version,
+		&i.ResumeData,
+		&i.ChangedSections,
+		&i.RestoredFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPaymentEvent = `-- name: CreatePaymentEvent :execrows
+
+INSERT INTO payment_events (event_id, event_type, user_id, package, credits)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (event_id) DO NOTHING
+`
+
+type CreatePaymentEventParams struct {
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	UserID    string `json:"user_id"`
+	Package   string `json:"package"`
+	Credits   int32  `json:"credits"`
+}
+
+// ===================
+// Payment Events
+// ===================
+// Records a processed webhook event; affects no rows if it was already processed
+func (q *Queries) CreatePaymentEvent(ctx context.Context, arg CreatePaymentEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createPaymentEvent,
+		arg.EventID,
+		arg.EventType,
+		arg.UserID,
+		arg.Package,
+		arg.Credits,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createUserCredits = `-- name: CreateUserCredits :one
 INSERT INTO user_credits (user_id, free_generations_used, free_generations_limit, paid_credits, total_generations)
 VALUES ($1, 0, 10, 0, 0)
@@ -187,6 +543,20 @@ func (q *Queries) CreateUserCredits(ctx context.Context, userID string) (UserCre
 	return i, err
 }
 
+const deleteApplication = `-- name: DeleteApplication :exec
+DELETE FROM applications WHERE id = $1 AND user_id = $2
+`
+
+type DeleteApplicationParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) DeleteApplication(ctx context.Context, arg DeleteApplicationParams) error {
+	_, err := q.db.Exec(ctx, deleteApplication, arg.ID, arg.UserID)
+	return err
+}
+
 const deleteCV = `-- name: DeleteCV :exec
 DELETE FROM generated_cvs WHERE id = $1 AND user_id = $2
 `
@@ -215,6 +585,30 @@ func (q *Queries) DeleteCoverLetter(ctx context.Context, arg DeleteCoverLetterPa
 	return err
 }
 
+const deleteJob = `-- name: DeleteJob :exec
+DELETE FROM jobs WHERE id = $1 AND user_id = $2
+`
+
+type DeleteJobParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) DeleteJob(ctx context.Context, arg DeleteJobParams) error {
+	_, err := q.db.Exec(ctx, deleteJob, arg.ID, arg.UserID)
+	return err
+}
+
+const deleteJobAnalyses = `-- name: DeleteJobAnalyses :exec
+DELETE FROM job_analyses WHERE job_id = $1
+`
+
+// Drops the cached analyses of a job once its description changes
+func (q *Queries) DeleteJobAnalyses(ctx context.Context, jobID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteJobAnalyses, jobID)
+	return err
+}
+
 const deleteMasterProfile = `-- name: DeleteMasterProfile :exec
 DELETE FROM master_profiles WHERE user_id = $1
 `
@@ -224,9 +618,68 @@ func (q *Queries) DeleteMasterProfile(ctx context.Context, userID string) error
 	return err
 }
 
+const getApplicationByUserAndId = `-- name: GetApplicationByUserAndId :one
+SELECT id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at FROM applications WHERE id = $1 AND user_id = $2 LIMIT 1
+`
+
+type GetApplicationByUserAndIdParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) GetApplicationByUserAndId(ctx context.Context, arg GetApplicationByUserAndIdParams) (Application, error) {
+	row := q.db.QueryRow(ctx, getApplicationByUserAndId, arg.ID, arg.UserID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getApplicationForUpdate = `-- name: GetApplicationForUpdate :one
+SELECT id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at FROM applications WHERE id = $1 AND user_id = $2 FOR UPDATE
+`
+
+type GetApplicationForUpdateParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+// Locks the application while its status is changed
+func (q *Queries) GetApplicationForUpdate(ctx context.Context, arg GetApplicationForUpdateParams) (Application, error) {
+	row := q.db.QueryRow(ctx, getApplicationForUpdate, arg.ID, arg.UserID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getCV = `-- name: GetCV :one
 
-SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at FROM generated_cvs WHERE id = $1 LIMIT 1
+SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score FROM generated_cvs WHERE id = $1 LIMIT 1
 `
 
 // ===================
@@ -249,12 +702,15 @@ func (q *Queries) GetCV(ctx context.Context, id pgtype.UUID) (GeneratedCv, error
 		&i.TemplateID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
 	)
 	return i, err
 }
 
 const getCVByUserAndId = `-- name: GetCVByUserAndId :one
-SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at FROM generated_cvs WHERE id = $1 AND user_id = $2 LIMIT 1
+SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score FROM generated_cvs WHERE id = $1 AND user_id = $2 LIMIT 1
 `
 
 type GetCVByUserAndIdParams struct {
@@ -279,13 +735,102 @@ func (q *Queries) GetCVByUserAndId(ctx context.Context, arg GetCVByUserAndIdPara
 		&i.TemplateID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
+	)
+	return i, err
+}
+
+const getCVForUpdate = `-- name: GetCVForUpdate :one
+SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score FROM generated_cvs WHERE id = $1 AND user_id = $2 FOR UPDATE
+`
+
+type GetCVForUpdateParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+// Locks the CV while it is written and its next revision is numbered
+func (q *Queries) GetCVForUpdate(ctx context.Context, arg GetCVForUpdateParams) (GeneratedCv, error) {
+	row := q.db.QueryRow(ctx, getCVForUpdate, arg.ID, arg.UserID)
+	var i GeneratedCv
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.JobUrl,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobDescription,
+		&i.CvData,
+		&i.MatchScore,
+		&i.AiSuggestions,
+		&i.TemplateID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
+	)
+	return i, err
+}
+
+const getCVRevision = `-- name: GetCVRevision :one
+SELECT id, cv_id, user_id, revision, name, cv_data, template_id, source, restored_from, created_at FROM cv_revisions
+WHERE cv_id = $1 AND user_id = $2 AND revision = $3
+LIMIT 1
+`
+
+type GetCVRevisionParams struct {
+	CvID     pgtype.UUID `json:"cv_id"`
+	UserID   string      `json:"user_id"`
+	Revision int32       `json:"revision"`
+}
+
+func (q *Queries) GetCVRevision(ctx context.Context, arg GetCVRevisionParams) (CvRevision, error) {
+	row := q.db.QueryRow(ctx, getCVRevision, arg.CvID, arg.UserID, arg.Revision)
+	var i CvRevision
+	err := row.Scan(
+		&i.ID,
+		&i.CvID,
+		&i.UserID,
+		&i.Revision,
+		&i.Name,
+		&i.CvData,
+		&i.TemplateID,
+		&i.Source,
+		&i.RestoredFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCVShareByToken = `-- name: GetCVShareByToken :one
+SELECT id, cv_id, user_id, token, password_hash, expires_at, revoked_at, view_count, last_viewed_at, created_at FROM cv_shares WHERE token = $1 LIMIT 1
+`
+
+func (q *Queries) GetCVShareByToken(ctx context.Context, token string) (CvShare, error) {
+	row := q.db.QueryRow(ctx, getCVShareByToken, token)
+	var i CvShare
+	err := row.Scan(
+		&i.ID,
+		&i.CvID,
+		&i.UserID,
+		&i.Token,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ViewCount,
+		&i.LastViewedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getCoverLetter = `-- name: GetCoverLetter :one
 
-SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at FROM cover_letters WHERE id = $1 LIMIT 1
+SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id FROM cover_letters WHERE id = $1 LIMIT 1
 `
 
 // ===================
@@ -303,12 +848,13 @@ func (q *Queries) GetCoverLetter(ctx context.Context, id pgtype.UUID) (CoverLett
 		&i.CompanyName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
 	)
 	return i, err
 }
 
 const getCoverLetterByUserAndId = `-- name: GetCoverLetterByUserAndId :one
-SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at FROM cover_letters WHERE id = $1 AND user_id = $2 LIMIT 1
+SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id FROM cover_letters WHERE id = $1 AND user_id = $2 LIMIT 1
 `
 
 type GetCoverLetterByUserAndIdParams struct {
@@ -328,6 +874,80 @@ func (q *Queries) GetCoverLetterByUserAndId(ctx context.Context, arg GetCoverLet
 		&i.CompanyName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+	)
+	return i, err
+}
+
+const getJobAnalysis = `-- name: GetJobAnalysis :one
+SELECT id, job_id, profile_version, analysis, created_at, description_hash FROM job_analyses
+WHERE job_id = $1 AND profile_version = $2 AND description_hash = $3
+LIMIT 1
+`
+
+type GetJobAnalysisParams struct {
+	JobID           pgtype.UUID `json:"job_id"`
+	ProfileVersion  int32       `json:"profile_version"`
+	DescriptionHash string      `json:"description_hash"`
+}
+
+func (q *Queries) GetJobAnalysis(ctx context.Context, arg GetJobAnalysisParams) (JobAnalysis, error) {
+	row := q.db.QueryRow(ctx, getJobAnalysis, arg.JobID, arg.ProfileVersion, arg.DescriptionHash)
+	var i JobAnalysis
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ProfileVersion,
+		&i.Analysis,
+		&i.CreatedAt,
+		&i.DescriptionHash,
+	)
+	return i, err
+}
+
+const getJobByUserAndId = `-- name: GetJobByUserAndId :one
+SELECT id, user_id, title, company_name, job_url, description, created_at, updated_at FROM jobs WHERE id = $1 AND user_id = $2 LIMIT 1
+`
+
+type GetJobByUserAndIdParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) GetJobByUserAndId(ctx context.Context, arg GetJobByUserAndIdParams) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobByUserAndId, arg.ID, arg.UserID)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLatestMasterProfileVersion = `-- name: GetLatestMasterProfileVersion :one
+SELECT id, user_id, version, resume_data, changed_sections, restored_from, created_at FROM master_profile_versions
+WHERE user_id = $1
+ORDER BY version DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestMasterProfileVersion(ctx context.Context, userID string) (MasterProfileVersion, error) {
+	row := q.db.QueryRow(ctx, getLatestMasterProfileVersion, userID)
+	var i MasterProfileVersion
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Version,
+		&i.ResumeData,
+		&i.ChangedSections,
+		&i.RestoredFrom,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -353,6 +973,86 @@ func (q *Queries) GetMasterProfile(ctx context.Context, userID string) (MasterPr
 	return i, err
 }
 
+const getMasterProfileForUpdate = `-- name: GetMasterProfileForUpdate :one
+SELECT id, user_id, resume_data, created_at, updated_at FROM master_profiles WHERE user_id = $1 FOR UPDATE
+`
+
+// Locks the profile while it is saved and its next version is numbered
+func (q *Queries) GetMasterProfileForUpdate(ctx context.Context, userID string) (MasterProfile, error) {
+	row := q.db.QueryRow(ctx, getMasterProfileForUpdate, userID)
+	var i MasterProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ResumeData,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getMasterProfileVersion = `-- name: GetMasterProfileVersion :one
+SELECT id, user_id, version, resume_data, changed_sections, restored_from, created_at FROM master_profile_versions
+WHERE user_id = $1 AND version = $2
+LIMIT 1
+`
+
+type GetMasterProfileVersionParams struct {
+	UserID  string `json:"user_id"`
+	Version int32  `json:"version"`
+}
+
+func (q *Queries) GetMasterProfileVersion(ctx context.Context, arg GetMasterProfileVersionParams) (MasterProfileVersion, error) {
+	row := q.db.QueryRow(ctx, getMasterProfileVersion, arg.UserID, arg.Version)
+	var i MasterProfileVersion
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Version,
+		&i.ResumeData,
+		&i.ChangedSections,
+		&i.RestoredFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMasterProfileWithVersion = `-- name: GetMasterProfileWithVersion :one
+SELECT mp.id, mp.user_id, mp.resume_data, mp.created_at, mp.updated_at, v.version
+FROM master_profiles mp
+LEFT JOIN LATERAL (
+    SELECT version FROM master_profile_versions
+    WHERE user_id = mp.user_id
+    ORDER BY version DESC
+    LIMIT 1
+) v ON TRUE
+WHERE mp.user_id = $1
+`
+
+type GetMasterProfileWithVersionRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     string             `json:"user_id"`
+	ResumeData []byte             `json:"resume_data"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	Version    pgtype.Int4        `json:"version"`
+}
+
+// Reads the profile with its latest version number in one snapshot
+func (q *Queries) GetMasterProfileWithVersion(ctx context.Context, userID string) (GetMasterProfileWithVersionRow, error) {
+	row := q.db.QueryRow(ctx, getMasterProfileWithVersion, userID)
+	var i GetMasterProfileWithVersionRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ResumeData,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const getOrCreateUserCredits = `-- name: GetOrCreateUserCredits :one
 INSERT INTO user_credits (user_id, free_generations_used, free_generations_limit, paid_credits, total_generations)
 VALUES ($1, 0, 10, 0, 0)
@@ -401,6 +1101,27 @@ func (q *Queries) GetUserCredits(ctx context.Context, userID string) (UserCredit
 	return i, err
 }
 
+const getUserCreditsForUpdate = `-- name: GetUserCreditsForUpdate :one
+SELECT id, user_id, free_generations_used, free_generations_limit, created_at, updated_at, paid_credits, total_generations FROM user_credits WHERE user_id = $1 FOR UPDATE
+`
+
+// Locks the user's credit row until the surrounding transaction ends
+func (q *Queries) GetUserCreditsForUpdate(ctx context.Context, userID string) (UserCredit, error) {
+	row := q.db.QueryRow(ctx, getUserCreditsForUpdate, userID)
+	var i UserCredit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FreeGenerationsUsed,
+		&i.FreeGenerationsLimit,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidCredits,
+		&i.TotalGenerations,
+	)
+	return i, err
+}
+
 const incrementCreditsUsed = `-- name: IncrementCreditsUsed :one
 UPDATE user_credits
 SET 
@@ -435,8 +1156,169 @@ func (q *Queries) IncrementCreditsUsed(ctx context.Context, userID string) (User
 	return i, err
 }
 
+const listApplicationStatusChanges = `-- name: ListApplicationStatusChanges :many
+SELECT id, application_id, from_status, to_status, note, changed_at FROM application_status_changes
+WHERE application_id = $1
+ORDER BY changed_at, id
+`
+
+func (q *Queries) ListApplicationStatusChanges(ctx context.Context, applicationID pgtype.UUID) ([]ApplicationStatusChange, error) {
+	rows, err := q.db.Query(ctx, listApplicationStatusChanges, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApplicationStatusChange{}
+	for rows.Next() {
+		var i ApplicationStatusChange
+		if err := rows.Scan(
+			&i.ID,
+			&i.ApplicationID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listApplicationsByUser = `-- name: ListApplicationsByUser :many
+SELECT id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at FROM applications
+WHERE user_id = $1 AND ($2::VARCHAR IS NULL OR status = $2)
+ORDER BY updated_at DESC
+`
+
+type ListApplicationsByUserParams struct {
+	UserID string      `json:"user_id"`
+	Status pgtype.Text `json:"status"`
+}
+
+func (q *Queries) ListApplicationsByUser(ctx context.Context, arg ListApplicationsByUserParams) ([]Application, error) {
+	rows, err := q.db.Query(ctx, listApplicationsByUser, arg.UserID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Application{}
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CvID,
+			&i.CoverLetterID,
+			&i.JobID,
+			&i.JobTitle,
+			&i.CompanyName,
+			&i.JobUrl,
+			&i.Status,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCVRevisions = `-- name: ListCVRevisions :many
+SELECT id, cv_id, user_id, revision, name, cv_data, template_id, source, restored_from, created_at FROM cv_revisions
+WHERE cv_id = $1 AND user_id = $2
+ORDER BY revision DESC
+`
+
+type ListCVRevisionsParams struct {
+	CvID   pgtype.UUID `json:"cv_id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) ListCVRevisions(ctx context.Context, arg ListCVRevisionsParams) ([]CvRevision, error) {
+	rows, err := q.db.Query(ctx, listCVRevisions, arg.CvID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CvRevision{}
+	for rows.Next() {
+		var i CvRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.CvID,
+			&i.UserID,
+			&i.Revision,
+			&i.Name,
+			&i.CvData,
+			&i.TemplateID,
+			&i.Source,
+			&i.RestoredFrom,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCVSharesByCV = `-- name: ListCVSharesByCV :many
+SELECT id, cv_id, user_id, token, password_hash, expires_at, revoked_at, view_count, last_viewed_at, created_at FROM cv_shares
+WHERE cv_id = $1 AND user_id = $2
+ORDER BY created_at DESC
+`
+
+type ListCVSharesByCVParams struct {
+	CvID   pgtype.UUID `json:"cv_id"`
+	UserID string      `json:"user_id"`
+}
+
+func (q *Queries) ListCVSharesByCV(ctx context.Context, arg ListCVSharesByCVParams) ([]CvShare, error) {
+	rows, err := q.db.Query(ctx, listCVSharesByCV, arg.CvID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CvShare{}
+	for rows.Next() {
+		var i CvShare
+		if err := rows.Scan(
+			&i.ID,
+			&i.CvID,
+			&i.UserID,
+			&i.Token,
+			&i.PasswordHash,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.ViewCount,
+			&i.LastViewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCVsByUser = `-- name: ListCVsByUser :many
-SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at FROM generated_cvs 
+SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score FROM generated_cvs 
 WHERE user_id = $1 
 ORDER BY created_at DESC
 `
@@ -464,6 +1346,9 @@ func (q *Queries) ListCVsByUser(ctx context.Context, userID string) ([]Generated
 			&i.TemplateID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.JobID,
+			&i.JobRequirements,
+			&i.KeywordScore,
 		); err != nil {
 			return nil, err
 		}
@@ -476,7 +1361,7 @@ func (q *Queries) ListCVsByUser(ctx context.Context, userID string) ([]Generated
 }
 
 const listCVsByUserPaginated = `-- name: ListCVsByUserPaginated :many
-SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at FROM generated_cvs 
+SELECT id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score FROM generated_cvs 
 WHERE user_id = $1 
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -511,6 +1396,9 @@ func (q *Queries) ListCVsByUserPaginated(ctx context.Context, arg ListCVsByUserP
 			&i.TemplateID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.JobID,
+			&i.JobRequirements,
+			&i.KeywordScore,
 		); err != nil {
 			return nil, err
 		}
@@ -523,7 +1411,7 @@ func (q *Queries) ListCVsByUserPaginated(ctx context.Context, arg ListCVsByUserP
 }
 
 const listCoverLettersByCV = `-- name: ListCoverLettersByCV :many
-SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at FROM cover_letters 
+SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id FROM cover_letters 
 WHERE cv_id = $1 
 ORDER BY created_at DESC
 `
@@ -546,6 +1434,7 @@ func (q *Queries) ListCoverLettersByCV(ctx context.Context, cvID pgtype.UUID) ([
 			&i.CompanyName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.JobID,
 		); err != nil {
 			return nil, err
 		}
@@ -558,7 +1447,7 @@ func (q *Queries) ListCoverLettersByCV(ctx context.Context, cvID pgtype.UUID) ([
 }
 
 const listCoverLettersByUser = `-- name: ListCoverLettersByUser :many
-SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at FROM cover_letters 
+SELECT id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id FROM cover_letters 
 WHERE user_id = $1 
 ORDER BY created_at DESC
 `
@@ -581,6 +1470,163 @@ func (q *Queries) ListCoverLettersByUser(ctx context.Context, userID string) ([]
 			&i.CompanyName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.JobID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCreditTransactionsByUser = `-- name: ListCreditTransactionsByUser :many
+SELECT id, user_id, amount, reason, cv_id, cover_letter_id, balance_after, created_at, refunded_transaction_id FROM credit_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListCreditTransactionsByUserParams struct {
+	UserID string `json:"user_id"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListCreditTransactionsByUser(ctx context.Context, arg ListCreditTransactionsByUserParams) ([]CreditTransaction, error) {
+	rows, err := q.db.Query(ctx, listCreditTransactionsByUser, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CreditTransaction{}
+	for rows.Next() {
+		var i CreditTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Amount,
+			&i.Reason,
+			&i.CvID,
+			&i.CoverLetterID,
+			&i.BalanceAfter,
+			&i.CreatedAt,
+			&i.RefundedTransactionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobsByUser = `-- name: ListJobsByUser :many
+SELECT id, user_id, title, company_name, job_url, description, created_at, updated_at FROM jobs
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListJobsByUser(ctx context.Context, userID string) ([]Job, error) {
+	rows, err := q.db.Query(ctx, listJobsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.CompanyName,
+			&i.JobUrl,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMasterProfileVersions = `-- name: ListMasterProfileVersions :many
+SELECT id, user_id, version, changed_sections, restored_from, created_at
+FROM master_profile_versions
+WHERE user_id = $1
+ORDER BY version DESC
+`
+
+type ListMasterProfileVersionsRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          string             `json:"user_id"`
+	Version         int32              `json:"version"`
+	ChangedSections []string           `json:"changed_sections"`
+	RestoredFrom    pgtype.Int4        `json:"restored_from"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListMasterProfileVersions(ctx context.Context, userID string) ([]ListMasterProfileVersionsRow, error) {
+	rows, err := q.db.Query(ctx, listMasterProfileVersions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMasterProfileVersionsRow{}
+	for rows.Next() {
+		var i ListMasterProfileVersionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Version,
+			&i.ChangedSections,
+			&i.RestoredFrom,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTopUsersByGenerations = `-- name: ListTopUsersByGenerations :many
+SELECT id, user_id, free_generations_used, free_generations_limit, created_at, updated_at, paid_credits, total_generations FROM user_credits
+ORDER BY total_generations DESC, user_id
+LIMIT $1
+`
+
+func (q *Queries) ListTopUsersByGenerations(ctx context.Context, limit int32) ([]UserCredit, error) {
+	rows, err := q.db.Query(ctx, listTopUsersByGenerations, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserCredit{}
+	for rows.Next() {
+		var i UserCredit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FreeGenerationsUsed,
+			&i.FreeGenerationsLimit,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PaidCredits,
+			&i.TotalGenerations,
 		); err != nil {
 			return nil, err
 		}
@@ -592,25 +1638,229 @@ func (q *Queries) ListCoverLettersByUser(ctx context.Context, userID string) ([]
 	return items, nil
 }
 
+const recordCVShareView = `-- name: RecordCVShareView :exec
+UPDATE cv_shares
+SET view_count = view_count + 1, last_viewed_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) RecordCVShareView(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, recordCVShareView, id)
+	return err
+}
+
+const refundCredit = `-- name: RefundCredit :one
+UPDATE user_credits
+SET
+    total_generations = total_generations - 1,
+    free_generations_used = CASE
+        WHEN $1::boolean THEN GREATEST(free_generations_used - 1, 0)
+        ELSE free_generations_used
+    END,
+    paid_credits = CASE
+        WHEN $1::boolean THEN paid_credits
+        ELSE paid_credits + 1
+    END,
+    updated_at = NOW()
+WHERE user_id = $2
+RETURNING id, user_id, free_generations_used, free_generations_limit, created_at, updated_at, paid_credits, total_generations
+`
+
+type RefundCreditParams struct {
+	RefundFree bool   `json:"refund_free"`
+	UserID     string `json:"user_id"`
+}
+
+// Returns a reserved credit to the pool (free or paid) it was taken from.
+// Free usage stays at or above zero in case an admin reset it meanwhile
+func (q *Queries) RefundCredit(ctx context.Context, arg RefundCreditParams) (UserCredit, error) {
+	row := q.db.QueryRow(ctx, refundCredit, arg.RefundFree, arg.UserID)
+	var i UserCredit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FreeGenerationsUsed,
+		&i.FreeGenerationsLimit,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidCredits,
+		&i.TotalGenerations,
+	)
+	return i, err
+}
+
+const resetFreeGenerationsUsed = `-- name: ResetFreeGenerationsUsed :one
+UPDATE user_credits
+SET free_generations_used = 0, updated_at = NOW()
+WHERE user_id = $1
+RETURNING id, user_id, free_generations_used, free_generations_limit, created_at, updated_at, paid_credits, total_generations
+`
+
+// Gives the user their full free allowance again
+func (q *Queries) ResetFreeGenerationsUsed(ctx context.Context, userID string) (UserCredit, error) {
+	row := q.db.QueryRow(ctx, resetFreeGenerationsUsed, userID)
+	var i UserCredit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FreeGenerationsUsed,
+		&i.FreeGenerationsLimit,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PaidCredits,
+		&i.TotalGenerations,
+	)
+	return i, err
+}
+
+const revokeCVShare = `-- name: RevokeCVShare :one
+UPDATE cv_shares
+SET revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1 AND cv_id = $2 AND user_id = $3
+RETURNING id, cv_id, user_id, token, password_hash, expires_at, revoked_at, view_count, last_viewed_at, created_at
+`
+
+type RevokeCVShareParams struct {
+	ID     pgtype.UUID `json:"id"`
+	CvID   pgtype.UUID `json:"cv_id"`
+	UserID string      `json:"user_id"`
+}
+
+// Revoking an already revoked share keeps its original revocation time
+func (q *Queries) RevokeCVShare(ctx context.Context, arg RevokeCVShareParams) (CvShare, error) {
+	row := q.db.QueryRow(ctx, revokeCVShare, arg.ID, arg.CvID, arg.UserID)
+	var i CvShare
+	err := row.Scan(
+		&i.ID,
+		&i.CvID,
+		&i.UserID,
+		&i.Token,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ViewCount,
+		&i.LastViewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const setCreditTransactionReference = `-- name: SetCreditTransactionReference :exec
+UPDATE credit_transactions
+SET cv_id = $2, cover_letter_id = $3
+WHERE id = $1
+`
+
+type SetCreditTransactionReferenceParams struct {
+	ID            pgtype.UUID `json:"id"`
+	CvID          pgtype.UUID `json:"cv_id"`
+	CoverLetterID pgtype.UUID `json:"cover_letter_id"`
+}
+
+// Links a debit to the CV or cover letter it paid for once that has been saved
+func (q *Queries) SetCreditTransactionReference(ctx context.Context, arg SetCreditTransactionReferenceParams) error {
+	_, err := q.db.Exec(ctx, setCreditTransactionReference, arg.ID, arg.CvID, arg.CoverLetterID)
+	return err
+}
+
+const updateApplication = `-- name: UpdateApplication :one
+UPDATE applications
+SET
+    cover_letter_id = COALESCE($3, cover_letter_id),
+    notes = COALESCE($4, notes),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at
+`
+
+type UpdateApplicationParams struct {
+	ID            pgtype.UUID `json:"id"`
+	UserID        string      `json:"user_id"`
+	CoverLetterID pgtype.UUID `json:"cover_letter_id"`
+	Notes         pgtype.Text `json:"notes"`
+}
+
+func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationParams) (Application, error) {
+	row := q.db.QueryRow(ctx, updateApplication,
+		arg.ID,
+		arg.UserID,
+		arg.CoverLetterID,
+		arg.Notes,
+	)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateApplicationStatus = `-- name: UpdateApplicationStatus :one
+UPDATE applications
+SET status = $3, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes, created_at, updated_at
+`
+
+type UpdateApplicationStatusParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID string      `json:"user_id"`
+	Status string      `json:"status"`
+}
+
+func (q *Queries) UpdateApplicationStatus(ctx context.Context, arg UpdateApplicationStatusParams) (Application, error) {
+	row := q.db.QueryRow(ctx, updateApplicationStatus, arg.ID, arg.UserID, arg.Status)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CvID,
+		&i.CoverLetterID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateCV = `-- name: UpdateCV :one
 UPDATE generated_cvs
 SET 
     name = COALESCE($3, name),
     cv_data = COALESCE($4, cv_data),
     template_id = COALESCE($5, template_id),
+    keyword_score = CASE WHEN $4::jsonb IS NULL THEN keyword_score ELSE $6 END,
     updated_at = NOW()
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at
+RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score
 `
 
 type UpdateCVParams struct {
-	ID         pgtype.UUID `json:"id"`
-	UserID     string      `json:"user_id"`
-	Name       pgtype.Text `json:"name"`
-	CvData     []byte      `json:"cv_data"`
-	TemplateID pgtype.Text `json:"template_id"`
+	ID           pgtype.UUID `json:"id"`
+	UserID       string      `json:"user_id"`
+	Name         pgtype.Text `json:"name"`
+	CvData       []byte      `json:"cv_data"`
+	TemplateID   pgtype.Text `json:"template_id"`
+	KeywordScore pgtype.Int4 `json:"keyword_score"`
 }
 
+// The keyword score is written with the CV data, NULL included, since it is
+// computed from it
 func (q *Queries) UpdateCV(ctx context.Context, arg UpdateCVParams) (GeneratedCv, error) {
 	row := q.db.QueryRow(ctx, updateCV,
 		arg.ID,
@@ -618,6 +1868,7 @@ func (q *Queries) UpdateCV(ctx context.Context, arg UpdateCVParams) (GeneratedCv
 		arg.Name,
 		arg.CvData,
 		arg.TemplateID,
+		arg.KeywordScore,
 	)
 	var i GeneratedCv
 	err := row.Scan(
@@ -634,6 +1885,9 @@ func (q *Queries) UpdateCV(ctx context.Context, arg UpdateCVParams) (GeneratedCv
 		&i.TemplateID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
 	)
 	return i, err
 }
@@ -642,7 +1896,7 @@ const updateCVName = `-- name: UpdateCVName :one
 UPDATE generated_cvs
 SET name = $3, updated_at = NOW()
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at
+RETURNING id, user_id, name, job_url, job_title, company_name, job_description, cv_data, match_score, ai_suggestions, template_id, created_at, updated_at, job_id, job_requirements, keyword_score
 `
 
 type UpdateCVNameParams struct {
@@ -668,6 +1922,9 @@ func (q *Queries) UpdateCVName(ctx context.Context, arg UpdateCVNameParams) (Gen
 		&i.TemplateID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+		&i.JobRequirements,
+		&i.KeywordScore,
 	)
 	return i, err
 }
@@ -676,7 +1933,7 @@ const updateCoverLetter = `-- name: UpdateCoverLetter :one
 UPDATE cover_letters
 SET content = $3, updated_at = NOW()
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, cv_id, content, job_title, company_name, created_at, updated_at
+RETURNING id, user_id, cv_id, content, job_title, company_name, created_at, updated_at, job_id
 `
 
 type UpdateCoverLetterParams struct {
@@ -697,6 +1954,51 @@ func (q *Queries) UpdateCoverLetter(ctx context.Context, arg UpdateCoverLetterPa
 		&i.CompanyName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.JobID,
+	)
+	return i, err
+}
+
+const updateJob = `-- name: UpdateJob :one
+UPDATE jobs
+SET
+    title = COALESCE($3, title),
+    company_name = COALESCE($4, company_name),
+    job_url = COALESCE($5, job_url),
+    description = COALESCE($6, description),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, title, company_name, job_url, description, created_at, updated_at
+`
+
+type UpdateJobParams struct {
+	ID          pgtype.UUID `json:"id"`
+	UserID      string      `json:"user_id"`
+	Title       pgtype.Text `json:"title"`
+	CompanyName pgtype.Text `json:"company_name"`
+	JobUrl      pgtype.Text `json:"job_url"`
+	Description pgtype.Text `json:"description"`
+}
+
+func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, updateJob,
+		arg.ID,
+		arg.UserID,
+		arg.Title,
+		arg.CompanyName,
+		arg.JobUrl,
+		arg.Description,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.CompanyName,
+		&i.JobUrl,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -726,6 +2028,39 @@ func (q *Queries) UpdateMasterProfile(ctx context.Context, arg UpdateMasterProfi
 	return i, err
 }
 
+const upsertJobAnalysis = `-- name: UpsertJobAnalysis :one
+INSERT INTO job_analyses (job_id, profile_version, description_hash, analysis)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (job_id, profile_version, description_hash) DO UPDATE SET analysis = EXCLUDED.analysis, created_at = NOW()
+RETURNING id, job_id, profile_version, analysis, created_at, description_hash
+`
+
+type UpsertJobAnalysisParams struct {
+	JobID           pgtype.UUID `json:"job_id"`
+	ProfileVersion  int32       `json:"profile_version"`
+	DescriptionHash string      `json:"description_hash"`
+	Analysis        []byte      `json:"analysis"`
+}
+
+func (q *Queries) UpsertJobAnalysis(ctx context.Context, arg UpsertJobAnalysisParams) (JobAnalysis, error) {
+	row := q.db.QueryRow(ctx, upsertJobAnalysis,
+		arg.JobID,
+		arg.ProfileVersion,
+		arg.DescriptionHash,
+		arg.Analysis,
+	)
+	var i JobAnalysis
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ProfileVersion,
+		&i.Analysis,
+		&i.CreatedAt,
+		&i.DescriptionHash,
+	)
+	return i, err
+}
+
 const upsertMasterProfile = `-- name: UpsertMasterProfile :one
 INSERT INTO master_profiles (user_id, resume_data)
 VALUES ($1, $2)
@@ -0,0 +1,237 @@
+// Package export renders JSON Resume CVs into downloadable document formats
+package export
+
+import (
+	"strings"
+	"time"
+
+	"cv-gen/backend/internal/models"
+)
+
+// document is a format-neutral view of a resume that every exporter renders.
+// Sections follow the order used by the web themes.
+type document struct {
+	Name     string
+	Label    string
+	Contact  []string
+	Summary  string
+	Sections []section
+}
+
+// section is a titled group of entries, e.g. "Work Experience"
+type section struct {
+	Title   string
+	Entries []entry
+}
+
+// entry is a single item within a section. Exporters show Title and Date on
+// one line, Subtitle below them, then Text and Bullets.
+type entry struct {
+	Title    string
+	Subtitle string
+	Date     string
+	URL      string
+	Text     string
+	Bullets  []string
+}
+
+// Date layouts for formatDate
+const (
+	// displayDateLayout matches the web themes, e.g. "Jan 2020"
+	displayDateLayout = "Jan 2006"
+	// atsDateLayout is the numeric form most applicant tracking systems parse
+	atsDateLayout = "01/2006"
+)
+
+// buildDocument flattens a resume into a document, rendering dates with
+// dateLayout
+func buildDocument(resume *models.JSONResume, dateLayout string) document {
+	var doc document
+	if resume == nil {
+		return doc
+	}
+
+	if b := resume.Basics; b != nil {
+		doc.Name = b.Name
+		doc.Label = b.Label
+		doc.Summary = b.Summary
+		doc.Contact = appendNonEmpty(doc.Contact, b.Email, b.Phone, b.URL)
+		if b.Location != nil {
+			doc.Contact = appendNonEmpty(doc.Contact, joinNonEmpty(", ", b.Location.City, b.Location.Region, b.Location.CountryCode))
+		}
+		for _, p := range b.Profiles {
+			doc.Contact = appendNonEmpty(doc.Contact, firstNonEmpty(p.URL, joinNonEmpty(": ", p.Network, p.Username)))
+		}
+	}
+
+	add := func(title string, entries []entry) {
+		if len(entries) > 0 {
+			doc.Sections = append(doc.Sections, section{Title: title, Entries: entries})
+		}
+	}
+
+	var work []entry
+	for _, w := range resume.Work {
+		work = append(work, entry{
+			Title:    w.Position,
+			Subtitle: joinNonEmpty(" | ", w.Name, w.Location),
+			Date:     formatDateRange(w.StartDate, w.EndDate, dateLayout),
+			URL:      w.URL,
+			Text:     w.Summary,
+			Bullets:  w.Highlights,
+		})
+	}
+	add("Work Experience", work)
+
+	var education []entry
+	for _, e := range resume.Education {
+		subtitle := e.Institution
+		if e.Score != "" {
+			subtitle = joinNonEmpty(" | ", subtitle, "Score: "+e.Score)
+		}
+		education = append(education, entry{
+			Title:    joinNonEmpty(" in ", e.StudyType, e.Area),
+			Subtitle: subtitle,
+			Date:     formatDateRange(e.StartDate, e.EndDate, dateLayout),
+			URL:      e.URL,
+			Bullets:  e.Courses,
+		})
+	}
+	add("Education", education)
+
+	var skills []entry
+	for _, s := range resume.Skills {
+		title := s.Name
+		if s.Level != "" {
+			title += " (" + s.Level + ")"
+		}
+		skills = append(skills, entry{Title: title, Text: strings.Join(s.Keywords, ", ")})
+	}
+	add("Skills", skills)
+
+	var projects []entry
+	for _, p := range resume.Projects {
+		projects = append(projects, entry{
+			Title:    p.Name,
+			Subtitle: joinNonEmpty(" | ", strings.Join(p.Roles, ", "), p.Entity),
+			Date:     formatDateRange(p.StartDate, p.EndDate, dateLayout),
+			URL:      p.URL,
+			Text:     joinNonEmpty("\n", p.Description, strings.Join(p.Keywords, ", ")),
+			Bullets:  p.Highlights,
+		})
+	}
+	add("Projects", projects)
+
+	var certificates []entry
+	for _, c := range resume.Certificates {
+		certificates = append(certificates, entry{
+			Title:    c.Name,
+			Subtitle: c.Issuer,
+			Date:     formatDate(c.Date, dateLayout),
+			URL:      c.URL,
+		})
+	}
+	add("Certifications", certificates)
+
+	var awards []entry
+	for _, a := range resume.Awards {
+		awards = append(awards, entry{
+			Title:    a.Title,
+			Subtitle: a.Awarder,
+			Date:     formatDate(a.Date, dateLayout),
+			Text:     a.Summary,
+		})
+	}
+	add("Awards", awards)
+
+	var publications []entry
+	for _, p := range resume.Publications {
+		publications = append(publications, entry{
+			Title:    p.Name,
+			Subtitle: p.Publisher,
+			Date:     formatDate(p.ReleaseDate, dateLayout),
+			URL:      p.URL,
+			Text:     p.Summary,
+		})
+	}
+	add("Publications", publications)
+
+	var languages []entry
+	for _, l := range resume.Languages {
+		languages = append(languages, entry{Title: l.Language, Text: l.Fluency})
+	}
+	add("Languages", languages)
+
+	var volunteer []entry
+	for _, v := range resume.Volunteer {
+		volunteer = append(volunteer, entry{
+			Title:    v.Position,
+			Subtitle: v.Organization,
+			Date:     formatDateRange(v.StartDate, v.EndDate, dateLayout),
+			URL:      v.URL,
+			Text:     v.Summary,
+			Bullets:  v.Highlights,
+		})
+	}
+	add("Volunteer", volunteer)
+
+	var interests []entry
+	for _, i := range resume.Interests {
+		interests = append(interests, entry{Title: i.Name, Text: strings.Join(i.Keywords, ", ")})
+	}
+	add("Interests", interests)
+
+	var references []entry
+	for _, r := range resume.References {
+		references = append(references, entry{Title: r.Name, Text: r.Reference})
+	}
+	add("References", references)
+
+	return doc
+}
+
+// formatDate renders an ISO 8601 date ("2020-01-15" or "2020-01") with
+// layout; years and other values are returned unchanged
+func formatDate(value, layout string) string {
+	for _, iso := range []string{"2006-01-02", "2006-01"} {
+		if t, err := time.Parse(iso, value); err == nil {
+			return t.Format(layout)
+		}
+	}
+	return value
+}
+
+// formatDateRange renders a start and end date, with a missing end date
+// meaning the role is current
+func formatDateRange(start, end, layout string) string {
+	if start == "" {
+		return formatDate(end, layout)
+	}
+	endText := "Present"
+	if end != "" {
+		endText = formatDate(end, layout)
+	}
+	return formatDate(start, layout) + " - " + endText
+}
+
+func appendNonEmpty(values []string, add ...string) []string {
+	for _, v := range add {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	return strings.Join(appendNonEmpty(nil, values...), sep)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
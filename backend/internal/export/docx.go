@@ -0,0 +1,251 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"cv-gen/backend/internal/models"
+)
+
+// A4 page with one inch margins, in twentieths of a point
+const (
+	docxPageWidth  = 11906
+	docxPageHeight = 16838
+	docxMargin     = 1440
+	docxTextWidth  = docxPageWidth - 2*docxMargin
+)
+
+// DOCX renders a resume as an Office Open XML (Word) document in the style of
+// the given template. Unknown template IDs fall back to themes.DefaultID.
+func DOCX(resume *models.JSONResume, templateID string) ([]byte, error) {
+	style := styleFor(templateID)
+	doc := buildDocument(resume, displayDateLayout)
+
+	var body strings.Builder
+	centered := style.Centered
+	if doc.Name != "" {
+		body.WriteString(docxParagraph("Title", centered, docxRun(doc.Name, "")))
+	}
+	if doc.Label != "" {
+		body.WriteString(docxParagraph("Subtitle", centered, docxRun(doc.Label, "")))
+	}
+	if len(doc.Contact) > 0 {
+		body.WriteString(docxParagraph("Contact", centered, docxRun(strings.Join(doc.Contact, "  |  "), "")))
+	}
+	if doc.Summary != "" {
+		body.WriteString(docxText("Normal", doc.Summary))
+	}
+
+	for _, sec := range doc.Sections {
+		body.WriteString(docxParagraph("Heading1", false, docxRun(sec.Title, "")))
+		for _, e := range sec.Entries {
+			// Title and date share a line, with the date on a right tab stop
+			runs := docxRun(e.Title, "")
+			if e.Date != "" {
+				runs += `<w:r><w:tab/></w:r>` + docxRun(e.Date, "EntryDate")
+			}
+			body.WriteString(docxParagraph("EntryTitle", false, runs))
+			if e.Subtitle != "" {
+				body.WriteString(docxParagraph("EntrySubtitle", false, docxRun(e.Subtitle, "")))
+			}
+			if e.Text != "" {
+				body.WriteString(docxText("Normal", e.Text))
+			}
+			for _, bullet := range e.Bullets {
+				body.WriteString(docxParagraph("ListBullet", false, docxRun(bullet, "")))
+			}
+		}
+	}
+
+	title := doc.Name
+	if title == "" {
+		title = "Resume"
+	}
+	return writeDOCX(title, style, body.String())
+}
+
+// CoverLetterDOCX renders a cover letter as a Word document. Blank lines in
+// content separate paragraphs.
+func CoverLetterDOCX(title, content, templateID string) ([]byte, error) {
+	style := styleFor(templateID)
+
+	var body strings.Builder
+	for _, paragraph := range splitParagraphs(content) {
+		lines := strings.Split(paragraph, "\n")
+		var runs strings.Builder
+		for i, line := range lines {
+			if i > 0 {
+				runs.WriteString(`<w:r><w:br/></w:r>`)
+			}
+			runs.WriteString(docxRun(line, ""))
+		}
+		body.WriteString(docxParagraph("Letter", false, runs.String()))
+	}
+
+	return writeDOCX(title, style, body.String())
+}
+
+// splitParagraphs splits text on blank lines, trimming surrounding whitespace
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
+
+// docxText writes text as one paragraph per line
+func docxText(style, text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			b.WriteString(docxParagraph(style, false, docxRun(line, "")))
+		}
+	}
+	return b.String()
+}
+
+func docxParagraph(style string, centered bool, runs string) string {
+	align := ""
+	if centered {
+		align = `<w:jc w:val="center"/>`
+	}
+	return fmt.Sprintf(`<w:p><w:pPr><w:pStyle w:val="%s"/>%s</w:pPr>%s</w:p>`, style, align, runs)
+}
+
+func docxRun(text, charStyle string) string {
+	props := ""
+	if charStyle != "" {
+		props = fmt.Sprintf(`<w:rPr><w:rStyle w:val="%s"/></w:rPr>`, charStyle)
+	}
+	return fmt.Sprintf(`<w:r>%s<w:t xml:space="preserve">%s</w:t></w:r>`, props, xmlEscape(text))
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// writeDOCX packages a document body into a .docx archive
+func writeDOCX(title string, style templateStyle, body string) ([]byte, error) {
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` + body +
+		fmt.Sprintf(`<w:sectPr><w:pgSz w:w="%d" w:h="%d"/><w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr>`,
+			docxPageWidth, docxPageHeight, docxMargin, docxMargin, docxMargin, docxMargin) +
+		`</w:body></w:document>`
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRootRels},
+		{"docProps/core.xml", fmt.Sprintf(docxCoreProps, xmlEscape(title))},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/document.xml", document},
+		{"word/styles.xml", docxStyles(style)},
+		{"word/numbering.xml", docxNumbering},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", f.name, err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// docxStyles builds the style sheet for a template. Sizes are in half-points;
+// child elements follow the order required by the WordprocessingML schema.
+func docxStyles(style templateStyle) string {
+	nameColor, labelColor, contactColor := style.Text, style.Muted, style.Muted
+	shading := ""
+	if style.HeaderBand {
+		// Word has no page-wide band, so shade the header paragraphs instead
+		nameColor, labelColor, contactColor = white, style.Accent, white
+		shading = fmt.Sprintf(`<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`, charcoal.hex())
+	}
+
+	nameBold := ""
+	if style.NameBold {
+		nameBold = `<w:b/>`
+	}
+
+	headingBorder := ""
+	if style.HeadingRule {
+		headingBorder = fmt.Sprintf(`<w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="%s"/></w:pBdr>`, style.Rule.hex())
+	}
+	headingCaps := ""
+	if style.UppercaseHeadings {
+		headingCaps = `<w:caps/>`
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:cs="%[1]s" w:eastAsia="%[1]s"/><w:color w:val="%[2]s"/><w:sz w:val="20"/></w:rPr></w:rPrDefault><w:pPrDefault><w:pPr><w:spacing w:after="40" w:line="264" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr>%[3]s<w:spacing w:after="40"/></w:pPr><w:rPr>%[4]s<w:color w:val="%[5]s"/><w:sz w:val="%[6]d"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr>%[3]s<w:spacing w:after="40"/></w:pPr><w:rPr><w:color w:val="%[7]s"/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Contact"><w:name w:val="Contact"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr>%[3]s<w:spacing w:after="200"/></w:pPr><w:rPr><w:color w:val="%[8]s"/><w:sz w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/>%[9]s<w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/>%[10]s<w:color w:val="%[11]s"/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="EntryTitle"><w:name w:val="Entry Title"/><w:basedOn w:val="Normal"/><w:next w:val="EntrySubtitle"/><w:pPr><w:keepNext/><w:tabs><w:tab w:val="right" w:pos="%[12]d"/></w:tabs><w:spacing w:before="120" w:after="0"/></w:pPr><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="EntryDate"><w:name w:val="Entry Date"/><w:rPr><w:b w:val="0"/><w:color w:val="%[13]s"/><w:sz w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="EntrySubtitle"><w:name w:val="Entry Subtitle"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/></w:pPr><w:rPr><w:color w:val="%[13]s"/><w:sz w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr><w:ind w:left="360" w:hanging="240"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="Letter"><w:name w:val="Letter"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:sz w:val="22"/></w:rPr></w:style>
+</w:styles>`,
+		style.Family.Name, style.Text.hex(),
+		shading, nameBold, nameColor.hex(), int(style.NameSize*2),
+		labelColor.hex(), contactColor.hex(),
+		headingBorder, headingCaps, style.Accent.hex(),
+		docxTextWidth, style.Muted.hex())
+}
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+const docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>
+</Relationships>`
+
+const docxCoreProps = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>%s</dc:title>
+<dc:creator>cv-gen</dc:creator>
+</cp:coreProperties>`
+
+const docxNumbering = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="0"><w:multiLevelType w:val="singleLevel"/><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="360" w:hanging="240"/></w:pPr></w:lvl></w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+</w:numbering>`
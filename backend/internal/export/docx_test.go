@@ -0,0 +1,115 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// docxParts unzips a .docx and checks every XML part is well formed
+func docxParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("%s is not well-formed XML: %v", f.Name, err)
+				}
+				break
+			}
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestDOCX(t *testing.T) {
+	resume := loadResume(t)
+	resume.Work[0].Highlights = append(resume.Work[0].Highlights, "Escaped <tags> & ampersands")
+
+	data, err := DOCX(resume, "academic")
+	if err != nil {
+		t.Fatalf("DOCX returned error: %v", err)
+	}
+	parts := docxParts(t, data)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/numbering.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	document := parts["word/document.xml"]
+	for _, want := range []string{"Jane Doe", "Work Experience", "Mar 2021 - Present", "Escaped &lt;tags&gt; &amp; ampersands"} {
+		if !strings.Contains(document, want) {
+			t.Errorf("expected document to contain %q", want)
+		}
+	}
+
+	highlights := 0
+	for _, w := range resume.Work {
+		highlights += len(w.Highlights)
+	}
+	if got := strings.Count(document, `<w:pStyle w:val="ListBullet"/>`); got != highlights {
+		t.Errorf("expected %d bullet paragraphs, got %d", highlights, got)
+	}
+
+	if !strings.Contains(parts["word/styles.xml"], `w:ascii="Times New Roman"`) {
+		t.Error("expected the academic template to use a serif font")
+	}
+}
+
+func TestDOCXTemplateStyles(t *testing.T) {
+	resume := loadResume(t)
+
+	modern, _ := DOCX(resume, "modern")
+	if !strings.Contains(docxParts(t, modern)["word/styles.xml"], `w:fill="1A1A1A"`) {
+		t.Error("expected the modern template to shade its header")
+	}
+
+	minimal, _ := DOCX(resume, "minimal")
+	minimalParts := docxParts(t, minimal)
+	if !strings.Contains(minimalParts["word/styles.xml"], "<w:caps/>") {
+		t.Error("expected the minimal template to use uppercase headings")
+	}
+	if !strings.Contains(minimalParts["word/document.xml"], `<w:jc w:val="center"/>`) {
+		t.Error("expected the minimal template to center its header")
+	}
+}
+
+func TestCoverLetterDOCX(t *testing.T) {
+	content := "Dear Hiring Manager,\n\nI am excited to apply.\nI bring six years of Go.\n\nSincerely,\nJane Doe"
+
+	data, err := CoverLetterDOCX("Cover Letter", content, "professional")
+	if err != nil {
+		t.Fatalf("CoverLetterDOCX returned error: %v", err)
+	}
+	document := docxParts(t, data)["word/document.xml"]
+
+	if got := strings.Count(document, `<w:pStyle w:val="Letter"/>`); got != 3 {
+		t.Errorf("expected 3 paragraphs, got %d", got)
+	}
+	if got := strings.Count(document, "<w:br/>"); got != 2 {
+		t.Errorf("expected 2 line breaks, got %d", got)
+	}
+}
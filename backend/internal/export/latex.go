@@ -0,0 +1,319 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/themes"
+)
+
+// moderncvTheme is the moderncv style and color a template is typeset with
+type moderncvTheme struct {
+	Style string
+	Color string
+}
+
+// moderncvThemes maps the IDs of the themes registry to moderncv themes. Every
+// registered theme needs an entry here, except the academic template, which
+// uses its own article-based layout instead.
+var moderncvThemes = map[string]moderncvTheme{
+	"professional": {Style: "classic", Color: "blue"},
+	"modern":       {Style: "banking", Color: "orange"},
+	"minimal":      {Style: "casual", Color: "grey"},
+}
+
+// academicTemplateID selects the publications-first article layout
+const academicTemplateID = "academic"
+
+// academicSectionOrder lists the sections the academic layout puts first;
+// the rest follow in document order
+var academicSectionOrder = []string{"Education", "Publications", "Awards", "Work Experience"}
+
+// LaTeX renders a resume as a complete LaTeX source file. The academic
+// template produces a publications-heavy article layout; every other
+// template uses moderncv, with unknown IDs falling back to themes.DefaultID.
+func LaTeX(resume *models.JSONResume, templateID string) []byte {
+	doc := buildDocument(resume, displayDateLayout)
+
+	var b strings.Builder
+	if templateID == academicTemplateID {
+		writeAcademicLaTeX(&b, doc)
+	} else {
+		theme, ok := moderncvThemes[templateID]
+		if !ok {
+			theme = moderncvThemes[themes.DefaultID]
+		}
+		var basics models.Basics
+		if resume != nil && resume.Basics != nil {
+			basics = *resume.Basics
+		}
+		writeModerncv(&b, doc, basics, theme)
+	}
+	return []byte(b.String())
+}
+
+func writeModerncv(b *strings.Builder, doc document, basics models.Basics, theme moderncvTheme) {
+	b.WriteString("\\documentclass[11pt,a4paper,sans]{moderncv}\n")
+	fmt.Fprintf(b, "\\moderncvstyle{%s}\n\\moderncvcolor{%s}\n", theme.Style, theme.Color)
+	b.WriteString("\\usepackage[utf8]{inputenc}\n\\usepackage[T1]{fontenc}\n\\usepackage[scale=0.8]{geometry}\n\n")
+
+	first, last := splitName(doc.Name)
+	fmt.Fprintf(b, "\\name{%s}{%s}\n", latexEscape(first), latexEscape(last))
+	if doc.Label != "" {
+		fmt.Fprintf(b, "\\title{%s}\n", latexEscape(doc.Label))
+	}
+	if loc := basics.Location; loc != nil {
+		street := joinNonEmpty(", ", loc.Address, loc.PostalCode)
+		city := joinNonEmpty(", ", loc.City, loc.Region)
+		if street != "" || city != "" || loc.CountryCode != "" {
+			fmt.Fprintf(b, "\\address{%s}{%s}{%s}\n", latexEscape(street), latexEscape(city), latexEscape(loc.CountryCode))
+		}
+	}
+	if basics.Phone != "" {
+		fmt.Fprintf(b, "\\phone[mobile]{%s}\n", latexEscape(basics.Phone))
+	}
+	if basics.Email != "" {
+		fmt.Fprintf(b, "\\email{%s}\n", latexEscape(basics.Email))
+	}
+	if basics.URL != "" {
+		fmt.Fprintf(b, "\\homepage{%s}\n", latexEscape(stripScheme(basics.URL)))
+	}
+	var profiles []string
+	for _, p := range basics.Profiles {
+		profiles = appendNonEmpty(profiles, latexEscape(firstNonEmpty(stripScheme(p.URL), joinNonEmpty(": ", p.Network, p.Username))))
+	}
+	if len(profiles) > 0 {
+		fmt.Fprintf(b, "\\extrainfo{%s}\n", strings.Join(profiles, " \\textbullet{} "))
+	}
+
+	b.WriteString("\n\\begin{document}\n\\makecvtitle\n")
+
+	if doc.Summary != "" {
+		b.WriteString("\n\\section{Summary}\n")
+		fmt.Fprintf(b, "\\cvitem{}{%s}\n", latexText(doc.Summary))
+	}
+
+	for _, s := range doc.Sections {
+		fmt.Fprintf(b, "\n\\section{%s}\n", latexEscape(s.Title))
+		compact := compactSection(s)
+		for _, e := range s.Entries {
+			if compact {
+				fmt.Fprintf(b, "\\cvitem{%s}{%s}\n", latexEscape(e.Title), latexText(e.Text))
+				continue
+			}
+			var description strings.Builder
+			if e.Text != "" {
+				description.WriteString(latexText(e.Text))
+			}
+			if len(e.Bullets) > 0 {
+				description.WriteString(latexItemize(e.Bullets))
+			}
+			title := latexEscape(e.Title)
+			if e.URL != "" {
+				title = latexLink(e.URL, title)
+			}
+			fmt.Fprintf(b, "\\cventry{%s}{%s}{%s}{}{}{%s}\n",
+				latexEscape(e.Date), title, latexEscape(e.Subtitle), description.String())
+		}
+	}
+
+	b.WriteString("\n\\end{document}\n")
+}
+
+func writeAcademicLaTeX(b *strings.Builder, doc document) {
+	b.WriteString(`\documentclass[11pt,a4paper]{article}
+\usepackage[utf8]{inputenc}
+\usepackage[T1]{fontenc}
+\usepackage{lmodern}
+\usepackage[margin=2.2cm]{geometry}
+\usepackage{enumitem}
+\usepackage{titlesec}
+\usepackage[hidelinks]{hyperref}
+
+\titleformat{\section}{\large\scshape}{}{0em}{}[\titlerule]
+\titlespacing*{\section}{0pt}{14pt}{6pt}
+\setlength{\parindent}{0pt}
+\setlist{nosep,leftmargin=1.5em}
+\pagestyle{plain}
+
+\begin{document}
+
+\begin{center}
+`)
+	if doc.Name != "" {
+		fmt.Fprintf(b, "{\\LARGE\\scshape %s}\\\\[4pt]\n", latexEscape(doc.Name))
+	}
+	if doc.Label != "" {
+		fmt.Fprintf(b, "%s\\\\[2pt]\n", latexEscape(doc.Label))
+	}
+	if len(doc.Contact) > 0 {
+		contact := make([]string, len(doc.Contact))
+		for i, c := range doc.Contact {
+			contact[i] = latexEscape(c)
+		}
+		fmt.Fprintf(b, "{\\small %s}\n", strings.Join(contact, " \\textbar{} "))
+	}
+	b.WriteString("\\end{center}\n")
+
+	if doc.Summary != "" {
+		fmt.Fprintf(b, "\n\\section*{Summary}\n%s\n", latexText(doc.Summary))
+	}
+
+	for _, s := range academicSections(doc.Sections) {
+		fmt.Fprintf(b, "\n\\section*{%s}\n", latexEscape(s.Title))
+		switch {
+		case s.Title == "Publications":
+			// Numbered the way publication lists usually are
+			b.WriteString("\\begin{enumerate}[label={[\\arabic*]}]\n")
+			for _, e := range s.Entries {
+				citation := latexEscape(e.Title)
+				if e.URL != "" {
+					citation = latexLink(e.URL, citation)
+				}
+				citation += "."
+				if venue := joinNonEmpty(", ", e.Subtitle, e.Date); venue != "" {
+					citation += " \\textit{" + latexEscape(venue) + "}."
+				}
+				if e.Text != "" {
+					citation += " " + latexText(e.Text)
+				}
+				fmt.Fprintf(b, "\\item %s\n", citation)
+			}
+			b.WriteString("\\end{enumerate}\n")
+		case compactSection(s):
+			for _, e := range s.Entries {
+				item := "\\textbf{" + latexEscape(e.Title) + "}"
+				if e.Text != "" {
+					item += ": " + latexText(e.Text)
+				}
+				fmt.Fprintf(b, "%s\\\\\n", item)
+			}
+		default:
+			for i, e := range s.Entries {
+				if i > 0 {
+					b.WriteString("\\medskip\n")
+				}
+				writeAcademicEntry(b, e, s.Title == "Education")
+			}
+		}
+	}
+
+	b.WriteString("\n\\end{document}\n")
+}
+
+// writeAcademicEntry writes a title line with the date flush right. Education
+// bullets are courses, listed inline rather than as a list.
+func writeAcademicEntry(b *strings.Builder, e entry, courses bool) {
+	title := latexEscape(e.Title)
+	if e.URL != "" {
+		title = latexLink(e.URL, title)
+	}
+	fmt.Fprintf(b, "\\textbf{%s} \\hfill %s\\\\\n", title, latexEscape(e.Date))
+	if e.Subtitle != "" {
+		fmt.Fprintf(b, "\\textit{%s}\\\\\n", latexEscape(e.Subtitle))
+	}
+	if e.Text != "" {
+		fmt.Fprintf(b, "%s\\\\\n", latexText(e.Text))
+	}
+	if len(e.Bullets) == 0 {
+		return
+	}
+	if courses {
+		escaped := make([]string, len(e.Bullets))
+		for i, c := range e.Bullets {
+			escaped[i] = latexEscape(c)
+		}
+		fmt.Fprintf(b, "\\textit{Coursework:} %s\\\\\n", strings.Join(escaped, "; "))
+		return
+	}
+	b.WriteString(latexItemize(e.Bullets) + "\n")
+}
+
+// academicSections moves the sections in academicSectionOrder to the front
+func academicSections(sections []section) []section {
+	ordered := make([]section, 0, len(sections))
+	placed := make(map[string]bool)
+	for _, title := range academicSectionOrder {
+		for _, s := range sections {
+			if s.Title == title {
+				ordered = append(ordered, s)
+				placed[title] = true
+			}
+		}
+	}
+	for _, s := range sections {
+		if !placed[s.Title] {
+			ordered = append(ordered, s)
+		}
+	}
+	return ordered
+}
+
+func latexItemize(items []string) string {
+	var b strings.Builder
+	b.WriteString("\\begin{itemize}")
+	for _, item := range items {
+		b.WriteString("\\item " + latexEscape(item))
+	}
+	b.WriteString("\\end{itemize}")
+	return b.String()
+}
+
+// latexText escapes text and keeps its line breaks
+func latexText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		lines = appendNonEmpty(lines, latexEscape(strings.TrimSpace(line)))
+	}
+	return strings.Join(lines, "\\newline ")
+}
+
+// latexLink wraps already-escaped text in a hyperlink to url
+func latexLink(url, text string) string {
+	return "\\href{" + latexURLEscaper.Replace(url) + "}{" + text + "}"
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+	`|`, `\textbar{}`,
+)
+
+// latexURLEscaper escapes the characters hyperref cannot take verbatim in a
+// macro argument
+var latexURLEscaper = strings.NewReplacer(
+	`\`, `%5C`,
+	`%`, `\%`,
+	`#`, `\#`,
+	`{`, `%7B`,
+	`}`, `%7D`,
+)
+
+// latexEscape escapes the characters LaTeX treats specially
+func latexEscape(s string) string {
+	return latexEscaper.Replace(s)
+}
+
+// splitName splits a full name into the first and last name moderncv expects
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+func stripScheme(url string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+}
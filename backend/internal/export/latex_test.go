@@ -0,0 +1,94 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"cv-gen/backend/internal/models"
+)
+
+func TestLaTeXEscape(t *testing.T) {
+	got := latexEscape(`50% of R&D_team {#1} costs $5 ~ ^ \`)
+	want := `50\% of R\&D\_team \{\#1\} costs \$5 \textasciitilde{} \textasciicircum{} \textbackslash{}`
+	if got != want {
+		t.Errorf("latexEscape() = %q, want %q", got, want)
+	}
+}
+
+func TestLaTeXModerncv(t *testing.T) {
+	resume := loadResume(t)
+	resume.Work[0].Highlights = append(resume.Work[0].Highlights, "Cut costs by 30% & shipped v2_beta")
+
+	tex := string(LaTeX(resume, "modern"))
+
+	for _, want := range []string{
+		`\documentclass[11pt,a4paper,sans]{moderncv}`,
+		`\moderncvstyle{banking}`,
+		`\name{Jane}{Doe}`,
+		`\email{jane@example.com}`,
+		`\section{Work Experience}`,
+		`\cventry{Mar 2021 - Present}{Senior Backend Engineer}{Acme Corp}`,
+		`\item Cut costs by 30\% \& shipped v2\_beta`,
+		`\cvitem{Backend}{Go, PostgreSQL, Kubernetes}`,
+	} {
+		if !strings.Contains(tex, want) {
+			t.Errorf("LaTeX missing %q:\n%s", want, tex)
+		}
+	}
+	checkBalanced(t, tex)
+
+	if fallback := string(LaTeX(resume, "unknown")); !strings.Contains(fallback, `\moderncvstyle{classic}`) {
+		t.Error("unknown template should fall back to the professional style")
+	}
+}
+
+func TestLaTeXAcademic(t *testing.T) {
+	resume := loadResume(t)
+	resume.Education[0].Courses = []string{"Distributed Systems", "Compilers"}
+	resume.Publications = []models.Publication{{Name: "Partitioning Ledgers", Publisher: "VLDB", ReleaseDate: "2022-09"}}
+	resume.Awards = []models.Award{{Title: "Best Paper", Awarder: "VLDB", Date: "2022"}}
+
+	tex := string(LaTeX(resume, "academic"))
+
+	for _, want := range []string{
+		`\documentclass[11pt,a4paper]{article}`,
+		`\textit{Coursework:} Distributed Systems; Compilers`,
+		`\item Partitioning Ledgers. \textit{VLDB, Sep 2022}.`,
+		`\section*{Awards}`,
+	} {
+		if !strings.Contains(tex, want) {
+			t.Errorf("LaTeX missing %q:\n%s", want, tex)
+		}
+	}
+	checkBalanced(t, tex)
+
+	// Education, publications and awards lead the academic layout
+	order := []string{`\section*{Education}`, `\section*{Publications}`, `\section*{Awards}`, `\section*{Work Experience}`, `\section*{Skills}`}
+	for i := 1; i < len(order); i++ {
+		if strings.Index(tex, order[i-1]) > strings.Index(tex, order[i]) {
+			t.Errorf("%s should come before %s", order[i-1], order[i])
+		}
+	}
+}
+
+// checkBalanced fails if unescaped braces do not pair up
+func checkBalanced(t *testing.T, tex string) {
+	t.Helper()
+	depth := 0
+	for i := 0; i < len(tex); i++ {
+		switch tex[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				t.Fatalf("unbalanced '}' at offset %d", i)
+			}
+		}
+	}
+	if depth != 0 {
+		t.Fatalf("%d unclosed braces", depth)
+	}
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"strings"
+
+	"cv-gen/backend/internal/models"
+)
+
+// Markdown renders a resume as CommonMark with one "##" heading per section
+func Markdown(resume *models.JSONResume) []byte {
+	doc := buildDocument(resume, displayDateLayout)
+
+	var b strings.Builder
+	if doc.Name != "" {
+		b.WriteString("# " + markdownEscape(doc.Name) + "\n\n")
+	}
+	if doc.Label != "" {
+		b.WriteString("**" + markdownEscape(doc.Label) + "**\n\n")
+	}
+	if len(doc.Contact) > 0 {
+		contact := make([]string, len(doc.Contact))
+		for i, c := range doc.Contact {
+			contact[i] = markdownEscape(c)
+		}
+		b.WriteString(strings.Join(contact, " | ") + "\n\n")
+	}
+	if doc.Summary != "" {
+		b.WriteString("## Summary\n\n" + markdownParagraphs(doc.Summary) + "\n\n")
+	}
+
+	for _, s := range doc.Sections {
+		b.WriteString("## " + markdownEscape(s.Title) + "\n\n")
+		if compactSection(s) {
+			// Skills, languages and the like read best as a single list
+			for _, e := range s.Entries {
+				line := "**" + markdownEscape(e.Title) + "**"
+				if e.Text != "" {
+					line += ": " + markdownEscape(e.Text)
+				}
+				b.WriteString("- " + line + "\n")
+			}
+			b.WriteString("\n")
+			continue
+		}
+		for _, e := range s.Entries {
+			writeMarkdownEntry(&b, e)
+		}
+	}
+
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+// CoverLetterMarkdown renders a cover letter as Markdown with title as its
+// heading; blank lines in content separate paragraphs
+func CoverLetterMarkdown(title, content string) []byte {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("# " + markdownEscape(title) + "\n\n")
+	}
+	paragraphs := splitParagraphs(content)
+	for i, p := range paragraphs {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(markdownParagraphs(p))
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+func writeMarkdownEntry(b *strings.Builder, e entry) {
+	if e.Title != "" {
+		title := markdownEscape(e.Title)
+		if e.URL != "" {
+			title = "[" + title + "](" + e.URL + ")"
+		}
+		b.WriteString("### " + title + "\n\n")
+	}
+
+	var meta []string
+	if e.Subtitle != "" {
+		meta = append(meta, markdownEscape(e.Subtitle))
+	}
+	if e.Date != "" {
+		meta = append(meta, "*"+markdownEscape(e.Date)+"*")
+	}
+	if len(meta) > 0 {
+		b.WriteString(strings.Join(meta, "  \n") + "\n\n")
+	}
+
+	if e.Text != "" {
+		b.WriteString(markdownParagraphs(e.Text) + "\n\n")
+	}
+	if len(e.Bullets) > 0 {
+		for _, bullet := range e.Bullets {
+			b.WriteString("- " + markdownEscape(bullet) + "\n")
+		}
+		b.WriteString("\n")
+	}
+}
+
+// compactSection reports whether every entry is a title with optional text,
+// as in skills or languages
+func compactSection(s section) bool {
+	for _, e := range s.Entries {
+		if e.Subtitle != "" || e.Date != "" || e.URL != "" || len(e.Bullets) > 0 || strings.Contains(e.Text, "\n") {
+			return false
+		}
+	}
+	return true
+}
+
+// markdownParagraphs escapes text and keeps its single line breaks as hard
+// breaks
+func markdownParagraphs(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = markdownEscape(strings.TrimSpace(line))
+	}
+	return strings.Join(lines, "  \n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+)
+
+// markdownEscape escapes characters that would otherwise be read as inline
+// formatting, links, HTML or headings
+func markdownEscape(s string) string {
+	s = markdownEscaper.Replace(s)
+	// A leading list marker would turn the line into a list item
+	if strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "+ ") {
+		s = `\` + s
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	resume := loadResume(t)
+	resume.Work[0].Highlights = append(resume.Work[0].Highlights, "Shipped *v2* of the [billing] API")
+
+	md := string(Markdown(resume))
+
+	for _, want := range []string{
+		"# Jane Doe\n",
+		"## Summary\n",
+		"## Work Experience\n",
+		"### Senior Backend Engineer\n",
+		"*Mar 2021 - Present*",
+		"- Migrated billing services",
+		`- Shipped \*v2\* of the \[billing\] API`,
+		"- **Backend**: Go, PostgreSQL, Kubernetes",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	// Sections keep the document order
+	if strings.Index(md, "## Work Experience") > strings.Index(md, "## Education") {
+		t.Error("work experience should come before education")
+	}
+}
+
+func TestCoverLetterMarkdown(t *testing.T) {
+	md := string(CoverLetterMarkdown("Backend Engineer at Acme", "Dear Hiring Manager,\n\nI am #1 at Go.\nThanks\n\n\nJane"))
+
+	want := "# Backend Engineer at Acme\n\nDear Hiring Manager,\n\nI am \\#1 at Go.  \nThanks\n\nJane\n"
+	if md != want {
+		t.Errorf("CoverLetterMarkdown() = %q, want %q", md, want)
+	}
+}
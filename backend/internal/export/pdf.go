@@ -0,0 +1,245 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"cv-gen/backend/internal/models"
+)
+
+// Layout constants in points
+const (
+	pdfMargin       = 50.0
+	pdfBodySize     = 10.0
+	pdfSmallSize    = 9.0
+	pdfHeadingSize  = 12.0
+	pdfLineFactor   = 1.35
+	pdfBulletIndent = 12.0
+)
+
+// PDF renders a resume as a paginated A4 PDF in the style of the given
+// template. Unknown template IDs fall back to themes.DefaultID.
+func PDF(resume *models.JSONResume, templateID string) ([]byte, error) {
+	style := styleFor(templateID)
+
+	doc := buildDocument(resume, displayDateLayout)
+	title := doc.Name
+	if title == "" {
+		title = "Resume"
+	}
+
+	l := &pdfLayout{
+		w:     newPDFWriter(title, style.Family.Regular, style.Family.Bold),
+		style: style,
+	}
+	l.newPage()
+	l.header(doc)
+	for _, s := range doc.Sections {
+		l.section(s)
+	}
+	l.footers()
+
+	return l.w.bytes()
+}
+
+// pdfLayout flows text down the page, starting new pages as needed
+type pdfLayout struct {
+	w     *pdfWriter
+	style templateStyle
+	page  int
+	// y is the top of the next line, measured from the bottom of the page
+	y float64
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = l.w.addPage()
+	l.y = pageHeight - pdfMargin
+}
+
+func (l *pdfLayout) contentWidth() float64 {
+	return pageWidth - 2*pdfMargin
+}
+
+// ensure starts a new page unless height points fit above the bottom margin
+func (l *pdfLayout) ensure(height float64) {
+	if l.y-height < pdfMargin+pdfSmallSize*2 {
+		l.newPage()
+	}
+}
+
+// lines writes wrapped text in the given font starting at indent, and
+// returns after the last line
+func (l *pdfLayout) lines(text string, font pdfFont, size float64, color rgb, indent float64, centered bool) {
+	lineHeight := size * pdfLineFactor
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrapText(paragraph, font, size, l.contentWidth()-indent) {
+			l.ensure(lineHeight)
+			x := pdfMargin + indent
+			if centered {
+				x = (pageWidth - textWidth(font, size, line)) / 2
+			}
+			l.w.text(l.page, x, l.y-size, font, size, color, line)
+			l.y -= lineHeight
+		}
+	}
+}
+
+func (l *pdfLayout) header(doc document) {
+	s := l.style
+	nameFont := s.Family.Regular
+	if s.NameBold {
+		nameFont = s.Family.Bold
+	}
+
+	nameColor, labelColor, contactColor := s.Text, s.Muted, s.Muted
+	if s.HeaderBand {
+		nameColor, labelColor, contactColor = white, s.Accent, white
+
+		// Measure the header so the band can be drawn behind it
+		height := pdfMargin + s.NameSize*pdfLineFactor + 2
+		if doc.Label != "" {
+			height += pdfHeadingSize * pdfLineFactor
+		}
+		contact := strings.Join(doc.Contact, "  |  ")
+		height += float64(len(wrapText(contact, s.Family.Regular, pdfSmallSize, l.contentWidth()))) * pdfSmallSize * pdfLineFactor
+		l.w.rect(l.page, 0, pageHeight-height, pageWidth, height, charcoal)
+		l.y = pageHeight - pdfMargin/2
+	}
+
+	if doc.Name != "" {
+		l.lines(doc.Name, nameFont, s.NameSize, nameColor, 0, s.Centered)
+	}
+	if doc.Label != "" {
+		l.lines(doc.Label, s.Family.Regular, pdfHeadingSize, labelColor, 0, s.Centered)
+	}
+	if len(doc.Contact) > 0 {
+		l.y -= 2
+		l.lines(strings.Join(doc.Contact, "  |  "), s.Family.Regular, pdfSmallSize, contactColor, 0, s.Centered)
+	}
+	if s.HeaderBand {
+		l.y -= pdfMargin / 2
+	}
+
+	if doc.Summary != "" {
+		l.y -= 10
+		l.lines(doc.Summary, s.Family.Regular, pdfBodySize, s.Text, 0, false)
+	}
+}
+
+func (l *pdfLayout) section(sec section) {
+	s := l.style
+	title := sec.Title
+	if s.UppercaseHeadings {
+		title = strings.ToUpper(title)
+	}
+
+	// Keep the heading with at least the first line of its first entry
+	l.y -= 12
+	l.ensure(pdfHeadingSize*pdfLineFactor + 4 + pdfBodySize*pdfLineFactor*2)
+	l.lines(title, s.Family.Bold, pdfHeadingSize, s.Accent, 0, false)
+	if s.HeadingRule {
+		l.w.line(l.page, pdfMargin, l.y+2, pageWidth-pdfMargin, l.y+2, 0.75, s.Rule)
+	}
+	l.y -= 4
+
+	for i, e := range sec.Entries {
+		if i > 0 {
+			l.y -= 6
+		}
+		l.entry(e)
+	}
+}
+
+func (l *pdfLayout) entry(e entry) {
+	s := l.style
+	lineHeight := pdfBodySize * pdfLineFactor
+
+	// Title on the left, date right-aligned on the same line
+	dateWidth := 0.0
+	if e.Date != "" {
+		dateWidth = textWidth(s.Family.Regular, pdfSmallSize, e.Date) + 10
+	}
+	titleLines := wrapText(e.Title, s.Family.Bold, pdfBodySize, l.contentWidth()-dateWidth)
+	for i, line := range titleLines {
+		l.ensure(lineHeight)
+		l.w.text(l.page, pdfMargin, l.y-pdfBodySize, s.Family.Bold, pdfBodySize, s.Text, line)
+		if i == 0 && e.Date != "" {
+			x := pageWidth - pdfMargin - textWidth(s.Family.Regular, pdfSmallSize, e.Date)
+			l.w.text(l.page, x, l.y-pdfBodySize, s.Family.Regular, pdfSmallSize, s.Muted, e.Date)
+		}
+		l.y -= lineHeight
+	}
+	if len(titleLines) == 0 && e.Date != "" {
+		l.lines(e.Date, s.Family.Regular, pdfSmallSize, s.Muted, 0, false)
+	}
+
+	if e.Subtitle != "" {
+		l.lines(e.Subtitle, s.Family.Regular, pdfSmallSize, s.Muted, 0, false)
+	}
+	if e.Text != "" {
+		l.lines(e.Text, s.Family.Regular, pdfBodySize, s.Text, 0, false)
+	}
+	for _, bullet := range e.Bullets {
+		l.bullet(bullet)
+	}
+}
+
+func (l *pdfLayout) bullet(text string) {
+	s := l.style
+	lineHeight := pdfBodySize * pdfLineFactor
+	for i, line := range wrapText(text, s.Family.Regular, pdfBodySize, l.contentWidth()-pdfBulletIndent) {
+		l.ensure(lineHeight)
+		if i == 0 {
+			l.w.text(l.page, pdfMargin+2, l.y-pdfBodySize, s.Family.Regular, pdfBodySize, s.Accent, "•")
+		}
+		l.w.text(l.page, pdfMargin+pdfBulletIndent, l.y-pdfBodySize, s.Family.Regular, pdfBodySize, s.Text, line)
+		l.y -= lineHeight
+	}
+}
+
+// footers numbers every page once the page count is known
+func (l *pdfLayout) footers() {
+	total := l.w.pageCount()
+	if total < 2 {
+		return
+	}
+	for page := 0; page < total; page++ {
+		label := fmt.Sprintf("Page %d of %d", page+1, total)
+		x := (pageWidth - textWidth(l.style.Family.Regular, pdfSmallSize, label)) / 2
+		l.w.text(page, x, pdfMargin/2, l.style.Family.Regular, pdfSmallSize, l.style.Muted, label)
+	}
+}
+
+// wrapText breaks text into lines no wider than width. Words longer than a
+// line are split across lines.
+func wrapText(text string, font pdfFont, size, width float64) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if textWidth(font, size, candidate) <= width {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		// Hard-break words that do not fit on a line of their own
+		for runes := []rune(word); len(runes) > 1 && textWidth(font, size, string(runes)) > width; runes = []rune(word) {
+			cut := len(runes) - 1
+			for cut > 1 && textWidth(font, size, string(runes[:cut])) > width {
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
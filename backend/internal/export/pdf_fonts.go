@@ -0,0 +1,118 @@
+package export
+
+// pdfFont identifies one of the standard Type 1 fonts every PDF reader ships,
+// so nothing needs to be embedded
+type pdfFont int
+
+const (
+	fontHelvetica pdfFont = iota
+	fontHelveticaBold
+	fontTimes
+	fontTimesBold
+)
+
+// fontFamily pairs a regular and a bold font. Name is the equivalent font
+// for formats that reference fonts by name, such as DOCX.
+type fontFamily struct {
+	Name    string
+	Regular pdfFont
+	Bold    pdfFont
+}
+
+var (
+	familySans  = fontFamily{Name: "Arial", Regular: fontHelvetica, Bold: fontHelveticaBold}
+	familySerif = fontFamily{Name: "Times New Roman", Regular: fontTimes, Bold: fontTimesBold}
+)
+
+// baseFontNames are the PostScript names of the standard fonts
+var baseFontNames = map[pdfFont]string{
+	fontHelvetica:     "Helvetica",
+	fontHelveticaBold: "Helvetica-Bold",
+	fontTimes:         "Times-Roman",
+	fontTimesBold:     "Times-Bold",
+}
+
+// fontWidths holds glyph advance widths (in 1/1000 em) for the printable
+// ASCII range 32-126, taken from the Adobe Core 14 AFM files
+var fontWidths = map[pdfFont][95]int{
+	fontHelvetica: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	fontHelveticaBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+	fontTimes: {
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	},
+	fontTimesBold: {
+		250, 333, 555, 500, 500, 1000, 833, 278, 333, 333, 500, 570, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 333, 333, 570, 570, 570, 500,
+		930, 722, 667, 722, 722, 667, 611, 778, 778, 389, 500, 778, 667, 944, 722, 778,
+		611, 778, 722, 556, 667, 722, 722, 1000, 722, 722, 667, 333, 278, 333, 581, 500,
+		333, 500, 556, 444, 556, 444, 333, 500, 556, 278, 333, 556, 278, 833, 556, 500,
+		556, 556, 444, 389, 333, 556, 500, 722, 500, 500, 444, 394, 220, 394, 520,
+	},
+}
+
+// winAnsiExtras maps the non-Latin-1 characters of WinAnsiEncoding (the
+// 0x80-0x9F block) that commonly appear in resumes
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '•': 0x95,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '–': 0x96, '—': 0x97,
+	'™': 0x99, 'Š': 0x8A, 'š': 0x9A, 'Œ': 0x8C, 'œ': 0x9C, 'Ž': 0x8E, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encodeWinAnsi converts text to the single-byte encoding used by the
+// standard fonts, replacing characters it cannot represent with '?'
+func encodeWinAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiExtras[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+// glyphWidth returns the advance width of an encoded byte in 1/1000 em.
+// Accented and other non-ASCII glyphs use the width of a lowercase 'o',
+// which is close enough for line breaking.
+func glyphWidth(font pdfFont, b byte) int {
+	widths := fontWidths[font]
+	switch {
+	case b >= 32 && b <= 126:
+		return widths[b-32]
+	case b == 0x95:
+		// bullet
+		return 350
+	case b == 0x97:
+		// em dash
+		return 1000
+	default:
+		return widths['o'-32]
+	}
+}
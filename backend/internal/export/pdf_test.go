@@ -0,0 +1,159 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/themes"
+)
+
+func loadResume(t *testing.T) *models.JSONResume {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "resume.json"))
+	if err != nil {
+		t.Fatalf("failed to read testdata: %v", err)
+	}
+	var resume models.JSONResume
+	if err := json.Unmarshal(data, &resume); err != nil {
+		t.Fatalf("failed to decode testdata: %v", err)
+	}
+	return &resume
+}
+
+var streamPattern = regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`)
+
+// pageContents inflates every content stream in a PDF
+func pageContents(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	var pages []string
+	for _, m := range streamPattern.FindAllSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			t.Fatalf("failed to inflate content stream: %v", err)
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("failed to inflate content stream: %v", err)
+		}
+		pages = append(pages, string(content))
+	}
+	return pages
+}
+
+// checkXref verifies that every cross-reference entry points at its object
+func checkXref(t *testing.T, pdf []byte) {
+	t.Helper()
+	start := bytes.LastIndex(pdf, []byte("startxref\n"))
+	if start < 0 {
+		t.Fatal("missing startxref")
+	}
+	offset, err := strconv.Atoi(strings.Fields(string(pdf[start+len("startxref\n"):]))[0])
+	if err != nil || !bytes.HasPrefix(pdf[offset:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	lines := strings.Split(string(pdf[offset:]), "\n")
+	for i, line := range lines[3:] {
+		if strings.HasPrefix(line, "trailer") {
+			break
+		}
+		objOffset, _ := strconv.Atoi(line[:10])
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(pdf[objOffset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, pdf[objOffset:objOffset+10])
+		}
+	}
+}
+
+func TestPDF(t *testing.T) {
+	resume := loadResume(t)
+
+	for _, templateID := range []string{"professional", "modern", "minimal", "academic"} {
+		t.Run(templateID, func(t *testing.T) {
+			pdf, err := PDF(resume, templateID)
+			if err != nil {
+				t.Fatalf("PDF returned error: %v", err)
+			}
+			if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+				t.Fatal("output is not framed as a PDF")
+			}
+			checkXref(t, pdf)
+
+			content := strings.Join(pageContents(t, pdf), "")
+			for _, want := range []string{"(Jane Doe)", "(Senior Backend Engineer)", "Mar 2021 - Present"} {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected page content to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestPDFTemplateFonts(t *testing.T) {
+	resume := loadResume(t)
+
+	academic, _ := PDF(resume, "academic")
+	if !bytes.Contains(academic, []byte("/BaseFont /Times-Roman")) {
+		t.Error("expected the academic template to use a serif font")
+	}
+
+	unknown, _ := PDF(resume, "does-not-exist")
+	professional, _ := PDF(resume, themes.DefaultID)
+	if !bytes.Equal(unknown, professional) {
+		t.Error("expected unknown templates to fall back to the default template")
+	}
+}
+
+func TestPDFPaginates(t *testing.T) {
+	resume := loadResume(t)
+	highlight := strings.Repeat("Delivered a measurable improvement to a production system. ", 4)
+	for i := 0; i < 40; i++ {
+		resume.Work = append(resume.Work, models.Work{
+			Name:       fmt.Sprintf("Company %d", i),
+			Position:   "Engineer",
+			StartDate:  "2010-01",
+			EndDate:    "2011-01",
+			Highlights: []string{highlight, highlight},
+		})
+	}
+
+	pdf, err := PDF(resume, "professional")
+	if err != nil {
+		t.Fatalf("PDF returned error: %v", err)
+	}
+	checkXref(t, pdf)
+
+	pages := pageContents(t, pdf)
+	if len(pages) < 3 {
+		t.Fatalf("expected the long resume to span several pages, got %d", len(pages))
+	}
+	if !strings.Contains(pages[len(pages)-1], fmt.Sprintf("(Page %d of %d)", len(pages), len(pages))) {
+		t.Error("expected the last page to be numbered")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("the quick brown fox jumps over the lazy dog", fontHelvetica, 10, 60)
+	for _, line := range lines {
+		if w := textWidth(fontHelvetica, 10, line); w > 60 {
+			t.Errorf("line %q is %.1fpt wide, limit 60", line, w)
+		}
+	}
+	if got := strings.Join(lines, " "); got != "the quick brown fox jumps over the lazy dog" {
+		t.Errorf("wrapping lost words: %q", got)
+	}
+
+	long := wrapText(strings.Repeat("x", 100), fontHelvetica, 10, 50)
+	if len(long) < 2 {
+		t.Errorf("expected an over-long word to be split, got %q", long)
+	}
+}
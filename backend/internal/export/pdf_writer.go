@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// pdfWriter accumulates page content streams and serializes them into a
+// minimal PDF 1.4 file. Coordinates are in points from the bottom-left corner.
+type pdfWriter struct {
+	fonts []pdfFont
+	pages []*bytes.Buffer
+	title string
+}
+
+func newPDFWriter(title string, fonts ...pdfFont) *pdfWriter {
+	return &pdfWriter{fonts: fonts, title: title}
+}
+
+// addPage starts a new page and returns its index
+func (w *pdfWriter) addPage() int {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	return len(w.pages) - 1
+}
+
+// pageCount returns the number of pages so far
+func (w *pdfWriter) pageCount() int {
+	return len(w.pages)
+}
+
+func (w *pdfWriter) fontResource(font pdfFont) string {
+	for i, f := range w.fonts {
+		if f == font {
+			return fmt.Sprintf("F%d", i+1)
+		}
+	}
+	// Fonts are registered up front; fall back to the first one
+	return "F1"
+}
+
+// text draws a single line of text with its baseline at (x, y)
+func (w *pdfWriter) text(page int, x, y float64, font pdfFont, size float64, color rgb, s string) {
+	buf := w.pages[page]
+	fmt.Fprintf(buf, "BT %s rg /%s %s Tf %s %s Td (", color.fill(), w.fontResource(font), num(size), num(x), num(y))
+	for _, b := range encodeWinAnsi(s) {
+		if b == '(' || b == ')' || b == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(b)
+	}
+	buf.WriteString(") Tj ET\n")
+}
+
+// line draws a straight line
+func (w *pdfWriter) line(page int, x1, y1, x2, y2, width float64, color rgb) {
+	fmt.Fprintf(w.pages[page], "%s RG %s w %s %s m %s %s l S\n",
+		color.fill(), num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// rect draws a filled rectangle
+func (w *pdfWriter) rect(page int, x, y, width, height float64, color rgb) {
+	fmt.Fprintf(w.pages[page], "%s rg %s %s %s %s re f\n",
+		color.fill(), num(x), num(y), num(width), num(height))
+}
+
+// textWidth returns the width of s in points
+func textWidth(font pdfFont, size float64, s string) float64 {
+	total := 0
+	for _, b := range encodeWinAnsi(s) {
+		total += glyphWidth(font, b)
+	}
+	return float64(total) * size / 1000
+}
+
+// bytes serializes the document
+func (w *pdfWriter) bytes() ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	// Objects are numbered from 1 in the order they are written
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// 1: catalog, 2: page tree, 3: info, then fonts, then page/content pairs
+	fontBase := 4
+	pageBase := fontBase + len(w.fonts)
+
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageBase+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object(fmt.Sprintf("<< /Title %s /Producer (cv-gen) >>", pdfString(w.title)))
+
+	fontRefs := make([]string, len(w.fonts))
+	for i, f := range w.fonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", baseFontNames[f]))
+		fontRefs[i] = fmt.Sprintf("/F%d %d 0 R", i+1, fontBase+i)
+	}
+
+	for i, content := range w.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(content.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			num(pageWidth), num(pageHeight), strings.Join(fontRefs, " "), pageBase+i*2+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+func (c rgb) fill() string {
+	return fmt.Sprintf("%s %s %s", num(c.R), num(c.G), num(c.B))
+}
+
+// num formats a number compactly for content streams
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// pdfString encodes text as a PDF literal string
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range encodeWinAnsi(s) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
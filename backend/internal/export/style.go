@@ -0,0 +1,91 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cv-gen/backend/internal/themes"
+)
+
+// rgb is a color with components in [0, 1]
+type rgb struct {
+	R, G, B float64
+}
+
+// hexColor parses a "#RRGGBB" color
+func hexColor(hex string) rgb {
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return rgb{}
+	}
+	return rgb{
+		R: float64(v>>16&0xFF) / 255,
+		G: float64(v>>8&0xFF) / 255,
+		B: float64(v&0xFF) / 255,
+	}
+}
+
+// templateStyle describes how a template looks in exported documents. The
+// values mirror the React themes of the same template ID.
+type templateStyle struct {
+	Family fontFamily
+	Text   rgb
+	Muted  rgb
+	Accent rgb
+	Rule   rgb
+
+	NameSize float64
+	// NameBold renders the name in the bold face
+	NameBold bool
+	// Centered centers the header (name, label and contact line)
+	Centered bool
+	// HeaderBand draws the header as white text on a dark band
+	HeaderBand bool
+	// UppercaseHeadings renders section headings in capitals
+	UppercaseHeadings bool
+	// HeadingRule draws a line under each section heading
+	HeadingRule bool
+}
+
+var (
+	charcoal = hexColor("#1A1A1A")
+	midGray  = hexColor("#6B6B6B")
+	amber    = hexColor("#F5A623")
+	ruleGray = hexColor("#D1D5DB")
+	white    = hexColor("#FFFFFF")
+)
+
+// templateStyles maps the IDs of the themes registry to their export style.
+// Every registered theme needs an entry here.
+var templateStyles = map[string]templateStyle{
+	"professional": {
+		Family: familySans, Text: charcoal, Muted: midGray, Accent: charcoal, Rule: ruleGray,
+		NameSize: 24, NameBold: true, HeadingRule: true,
+	},
+	"modern": {
+		Family: familySans, Text: charcoal, Muted: midGray, Accent: amber, Rule: amber,
+		NameSize: 24, NameBold: true, HeaderBand: true, HeadingRule: true,
+	},
+	"minimal": {
+		Family: familySans, Text: charcoal, Muted: midGray, Accent: midGray, Rule: ruleGray,
+		NameSize: 22, Centered: true, UppercaseHeadings: true,
+	},
+	"academic": {
+		Family: familySerif, Text: charcoal, Muted: midGray, Accent: charcoal, Rule: charcoal,
+		NameSize: 24, Centered: true, HeadingRule: true,
+	},
+}
+
+// styleFor returns the style of a template, falling back to the default theme
+func styleFor(templateID string) templateStyle {
+	if style, ok := templateStyles[templateID]; ok {
+		return style
+	}
+	return templateStyles[themes.DefaultID]
+}
+
+// hex formats the color as "RRGGBB"
+func (c rgb) hex() string {
+	return fmt.Sprintf("%02X%02X%02X", int(c.R*255+0.5), int(c.G*255+0.5), int(c.B*255+0.5))
+}
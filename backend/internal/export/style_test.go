@@ -0,0 +1,35 @@
+package export
+
+import (
+	"testing"
+
+	"cv-gen/backend/internal/themes"
+)
+
+func TestTemplateStylesCoverThemes(t *testing.T) {
+	registered := map[string]bool{}
+	for _, theme := range themes.List() {
+		registered[theme.ID] = true
+
+		if _, ok := templateStyles[theme.ID]; !ok {
+			t.Errorf("theme %q has no export style", theme.ID)
+		}
+		if _, ok := moderncvThemes[theme.ID]; !ok && theme.ID != academicTemplateID {
+			t.Errorf("theme %q has no moderncv theme", theme.ID)
+		}
+	}
+
+	for id := range templateStyles {
+		if !registered[id] {
+			t.Errorf("export style %q is not a registered theme", id)
+		}
+	}
+	for id := range moderncvThemes {
+		if !registered[id] {
+			t.Errorf("moderncv theme %q is not a registered theme", id)
+		}
+	}
+	if !registered[academicTemplateID] {
+		t.Errorf("academic layout %q is not a registered theme", academicTemplateID)
+	}
+}
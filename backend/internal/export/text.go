@@ -0,0 +1,84 @@
+package export
+
+import (
+	"strings"
+
+	"cv-gen/backend/internal/models"
+)
+
+// atsHeadings maps document section titles to the headings applicant
+// tracking systems recognise; unlisted titles are upper-cased as they are
+var atsHeadings = map[string]string{
+	"Volunteer": "VOLUNTEER EXPERIENCE",
+}
+
+// atsPunctuation replaces typographic characters that some parsers mangle
+// with their ASCII equivalents
+var atsPunctuation = strings.NewReplacer(
+	"‘", "'", "’", "'",
+	"“", `"`, "”", `"`,
+	"–", "-", "—", "-",
+	"•", "-", "·", "-",
+	"…", "...",
+	" ", " ",
+	"\t", " ",
+)
+
+// ATSText renders a resume as plain text for applicant tracking systems:
+// standard upper-case section headings, one field per line, "-" bullets,
+// numeric MM/YYYY dates and no tables or columns
+func ATSText(resume *models.JSONResume) []byte {
+	doc := buildDocument(resume, atsDateLayout)
+
+	var b strings.Builder
+	writeLine := func(s string) {
+		if s = strings.TrimSpace(atsPunctuation.Replace(s)); s != "" {
+			b.WriteString(s + "\n")
+		}
+	}
+	writeText := func(s string) {
+		for _, line := range strings.Split(s, "\n") {
+			writeLine(line)
+		}
+	}
+
+	writeLine(strings.ToUpper(doc.Name))
+	writeLine(doc.Label)
+	for _, c := range doc.Contact {
+		writeLine(c)
+	}
+
+	if doc.Summary != "" {
+		b.WriteString("\nSUMMARY\n")
+		writeText(doc.Summary)
+	}
+
+	for _, s := range doc.Sections {
+		heading, ok := atsHeadings[s.Title]
+		if !ok {
+			heading = strings.ToUpper(s.Title)
+		}
+		b.WriteString("\n" + heading + "\n")
+
+		compact := compactSection(s)
+		for i, e := range s.Entries {
+			if compact {
+				writeLine(joinNonEmpty(": ", e.Title, e.Text))
+				continue
+			}
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			writeLine(e.Title)
+			writeLine(e.Subtitle)
+			writeLine(e.Date)
+			writeLine(e.URL)
+			writeText(e.Text)
+			for _, bullet := range e.Bullets {
+				writeLine("- " + bullet)
+			}
+		}
+	}
+
+	return []byte(strings.TrimLeft(b.String(), "\n"))
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestATSText(t *testing.T) {
+	resume := loadResume(t)
+	resume.Work[0].Highlights = append(resume.Work[0].Highlights, "Led the “ledger” rewrite — end to end")
+
+	text := string(ATSText(resume))
+
+	for _, want := range []string{
+		"JANE DOE\n",
+		"\nSUMMARY\n",
+		"\nWORK EXPERIENCE\nSenior Backend Engineer\nAcme Corp\n03/2021 - Present\n",
+		"01/2018 - 02/2021\n",
+		"- Led the \"ledger\" rewrite - end to end\n",
+		"\nSKILLS\nBackend: Go, PostgreSQL, Kubernetes\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("ATS text missing %q:\n%s", want, text)
+		}
+	}
+
+	for _, r := range text {
+		if r > 127 {
+			t.Errorf("ATS text contains non-ASCII rune %q", r)
+		}
+	}
+}
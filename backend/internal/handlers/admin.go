@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	adminSvc "cv-gen/backend/internal/services/admin"
+)
+
+// AdminHandler holds dependencies for admin handlers
+type AdminHandler struct {
+	service      *adminSvc.Service
+	adminUserIDs []string
+}
+
+// NewAdminHandler creates a new admin handler. adminUserIDs are allowed in
+// addition to users with the admin role claim.
+func NewAdminHandler(service *adminSvc.Service, adminUserIDs []string) *AdminHandler {
+	return &AdminHandler{
+		service:      service,
+		adminUserIDs: adminUserIDs,
+	}
+}
+
+// AdminUserIDs returns the user IDs allowed to use the admin API
+func (h *AdminHandler) AdminUserIDs() []string {
+	return h.adminUserIDs
+}
+
+// GrantCreditsRequest represents the request body for granting credits
+type GrantCreditsRequest struct {
+	// Amount of paid credits to add; negative to revoke
+	Amount int32 `json:"amount"`
+}
+
+// GetUserCredits handles GET /api/admin/users/:user_id/credits
+func (h *AdminHandler) GetUserCredits(c echo.Context) error {
+	userID := c.Param("user_id")
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "user id is required")
+	}
+
+	result, err := h.service.GetUserCredits(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, adminSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get credits")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GrantCredits handles POST /api/admin/users/:user_id/credits
+func (h *AdminHandler) GrantCredits(c echo.Context) error {
+	userID := c.Param("user_id")
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "user id is required")
+	}
+
+	var req GrantCreditsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	result, err := h.service.GrantCredits(c.Request().Context(), userID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, adminSvc.ErrInvalidAmount):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, adminSvc.ErrInsufficientPaidCredits):
+			return echo.NewHTTPError(http.StatusConflict, "user does not have enough paid credits to revoke")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to grant credits")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ResetFreeUsage handles POST /api/admin/users/:user_id/credits/reset-free
+func (h *AdminHandler) ResetFreeUsage(c echo.Context) error {
+	userID := c.Param("user_id")
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "user id is required")
+	}
+
+	result, err := h.service.ResetFreeUsage(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to reset free usage")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// TopUsers handles GET /api/admin/top-users
+func (h *AdminHandler) TopUsers(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	users, err := h.service.TopUsers(c.Request().Context(), limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list top users")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"users": users,
+	})
+}
@@ -38,18 +38,24 @@ func (h *AIHandler) AnalyzeJob(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
-	if req.JobDescription == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "job_description is required")
+	if req.JobDescription == "" && req.JobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job_description or job_id is required")
 	}
 
-	analysis, err := h.aiService.AnalyzeJob(c.Request().Context(), userID, req.JobDescription)
+	analysis, err := h.aiService.AnalyzeJob(c.Request().Context(), userID, &req)
 	if err != nil {
 		if errors.Is(err, ai.ErrProfileNotFound) {
 			return echo.NewHTTPError(http.StatusBadRequest, "please complete your profile before analyzing jobs")
 		}
+		if errors.Is(err, ai.ErrJobNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "job not found")
+		}
 		if errors.Is(err, ai.ErrEmptyJobDescription) {
 			return echo.NewHTTPError(http.StatusBadRequest, "job description cannot be empty")
 		}
+		if httpErr := aiProviderError(err); httpErr != nil {
+			return httpErr
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to analyze job: "+err.Error())
 	}
 
@@ -74,27 +80,50 @@ func (h *AIHandler) GenerateCV(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
-	if req.JobDescription == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "job_description is required")
+	if req.JobDescription == "" && req.JobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job_description or job_id is required")
 	}
 
 	response, err := h.aiService.GenerateCV(c.Request().Context(), userID, &req)
 	if err != nil {
-		if errors.Is(err, ai.ErrOutOfCredits) {
-			return echo.NewHTTPError(http.StatusPaymentRequired, "you have used all your free generation credits")
-		}
-		if errors.Is(err, ai.ErrProfileNotFound) {
-			return echo.NewHTTPError(http.StatusBadRequest, "please complete your profile before generating CVs")
-		}
-		if errors.Is(err, ai.ErrEmptyJobDescription) {
-			return echo.NewHTTPError(http.StatusBadRequest, "job description cannot be empty")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate CV: "+err.Error())
+		return generateCVError(err)
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// GenerateCVStream handles POST /api/ai/generate-cv/stream
+// Streams progress and partial CV output as Server-Sent Events
+func (h *AIHandler) GenerateCVStream(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.aiService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "AI service not available")
+	}
+
+	var req ai.GenerateCVRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if req.JobDescription == "" && req.JobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job_description or job_id is required")
+	}
+
+	stream := newSSEWriter(c)
+	response, err := h.aiService.GenerateCVStream(c.Request().Context(), userID, &req, func(event ai.StreamEvent) error {
+		return stream.Send(event.Type, event.Data)
+	})
+	if err != nil {
+		return stream.SendError(generateCVError(err))
+	}
+
+	return stream.Send(ai.EventComplete, response)
+}
+
 // GetCredits handles GET /api/ai/credits (alternative endpoint)
 func (h *AIHandler) GetCredits(c echo.Context) error {
 	userID, err := appMiddleware.RequireUserID(c)
@@ -130,24 +159,116 @@ func (h *AIHandler) GenerateCoverLetter(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
-	if req.JobTitle == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "job_title is required")
+	// A saved job can supply the title and company
+	if req.JobID == "" {
+		if req.JobTitle == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "job_title is required")
+		}
+		if req.CompanyName == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "company_name is required")
+		}
 	}
 
-	if req.CompanyName == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "company_name is required")
+	response, err := h.aiService.GenerateCoverLetter(c.Request().Context(), userID, &req)
+	if err != nil {
+		return generateCoverLetterError(err)
 	}
 
-	response, err := h.aiService.GenerateCoverLetter(c.Request().Context(), userID, &req)
+	return c.JSON(http.StatusOK, response)
+}
+
+// GenerateCoverLetterStream handles POST /api/ai/generate-cover-letter/stream
+// Streams the cover letter text as Server-Sent Events while it is written
+func (h *AIHandler) GenerateCoverLetterStream(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
 	if err != nil {
-		if errors.Is(err, ai.ErrOutOfCredits) {
-			return echo.NewHTTPError(http.StatusPaymentRequired, "you have used all your free generation credits")
+		return err
+	}
+
+	if h.aiService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "AI service not available")
+	}
+
+	var req ai.GenerateCoverLetterRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// A saved job can supply the title and company
+	if req.JobID == "" {
+		if req.JobTitle == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "job_title is required")
 		}
-		if errors.Is(err, ai.ErrProfileNotFound) {
-			return echo.NewHTTPError(http.StatusBadRequest, "please complete your profile before generating cover letters")
+		if req.CompanyName == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "company_name is required")
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate cover letter: "+err.Error())
 	}
 
-	return c.JSON(http.StatusOK, response)
+	stream := newSSEWriter(c)
+	response, err := h.aiService.GenerateCoverLetterStream(c.Request().Context(), userID, &req, func(event ai.StreamEvent) error {
+		return stream.Send(event.Type, event.Data)
+	})
+	if err != nil {
+		return stream.SendError(generateCoverLetterError(err))
+	}
+
+	return stream.Send(ai.EventComplete, response)
+}
+
+// generateCVError maps CV generation errors to HTTP errors
+func generateCVError(err error) *echo.HTTPError {
+	if errors.Is(err, ai.ErrOutOfCredits) {
+		return echo.NewHTTPError(http.StatusPaymentRequired, "you have used all your free generation credits")
+	}
+	if errors.Is(err, ai.ErrProfileNotFound) {
+		return echo.NewHTTPError(http.StatusBadRequest, "please complete your profile before generating CVs")
+	}
+	if errors.Is(err, ai.ErrJobNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "job not found")
+	}
+	if errors.Is(err, ai.ErrEmptyJobDescription) {
+		return echo.NewHTTPError(http.StatusBadRequest, "job description cannot be empty")
+	}
+	if errors.Is(err, ai.ErrInvalidTailoring) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if httpErr := aiProviderError(err); httpErr != nil {
+		return httpErr
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate CV: "+err.Error())
+}
+
+// generateCoverLetterError maps cover letter generation errors to HTTP errors
+func generateCoverLetterError(err error) *echo.HTTPError {
+	if errors.Is(err, ai.ErrOutOfCredits) {
+		return echo.NewHTTPError(http.StatusPaymentRequired, "you have used all your free generation credits")
+	}
+	if errors.Is(err, ai.ErrProfileNotFound) {
+		return echo.NewHTTPError(http.StatusBadRequest, "please complete your profile before generating cover letters")
+	}
+	if errors.Is(err, ai.ErrJobNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "job not found")
+	}
+	if errors.Is(err, ai.ErrMissingJobDetails) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if httpErr := aiProviderError(err); httpErr != nil {
+		return httpErr
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate cover letter: "+err.Error())
+}
+
+// aiProviderError maps typed LLM provider errors to HTTP errors, or returns nil
+func aiProviderError(err error) *echo.HTTPError {
+	switch {
+	case errors.Is(err, ai.ErrRateLimited):
+		return echo.NewHTTPError(http.StatusTooManyRequests, "the AI provider is busy, please try again in a moment")
+	case errors.Is(err, ai.ErrTimeout):
+		return echo.NewHTTPError(http.StatusGatewayTimeout, "the AI provider took too long to respond, please try again")
+	case errors.Is(err, ai.ErrProviderUnavailable):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "the AI provider is temporarily unavailable, please try again later")
+	case errors.Is(err, ai.ErrInvalidResponse):
+		return echo.NewHTTPError(http.StatusBadGateway, "the AI provider returned an invalid response, please try again")
+	}
+	return nil
 }
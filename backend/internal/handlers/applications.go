@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	applicationsSvc "cv-gen/backend/internal/services/applications"
+)
+
+// ApplicationHandler holds dependencies for application tracking handlers
+type ApplicationHandler struct {
+	service *applicationsSvc.Service
+}
+
+// NewApplicationHandler creates a new application handler
+func NewApplicationHandler(service *applicationsSvc.Service) *ApplicationHandler {
+	return &ApplicationHandler{
+		service: service,
+	}
+}
+
+// ListApplications handles GET /api/applications?status=
+func (h *ApplicationHandler) ListApplications(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	applications, err := h.service.ListApplications(c.Request().Context(), userID, c.QueryParam("status"))
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrInvalidStatus) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list applications")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"applications": applications,
+	})
+}
+
+// GetApplication handles GET /api/applications/:id
+func (h *ApplicationHandler) GetApplication(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	applicationID := c.Param("id")
+	if applicationID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "application id is required")
+	}
+
+	application, err := h.service.GetApplication(c.Request().Context(), userID, applicationID)
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "application not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get application")
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
+
+// CreateApplication handles POST /api/applications
+func (h *ApplicationHandler) CreateApplication(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	var input applicationsSvc.CreateApplicationInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	application, err := h.service.CreateApplication(c.Request().Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrInvalidData) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create application")
+	}
+
+	return c.JSON(http.StatusCreated, application)
+}
+
+// UpdateApplication handles PUT /api/applications/:id
+func (h *ApplicationHandler) UpdateApplication(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	applicationID := c.Param("id")
+	if applicationID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "application id is required")
+	}
+
+	var input applicationsSvc.UpdateApplicationInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	application, err := h.service.UpdateApplication(c.Request().Context(), userID, applicationID, input)
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "application not found")
+		}
+		if errors.Is(err, applicationsSvc.ErrInvalidData) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update application")
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
+
+// ChangeStatus handles POST /api/applications/:id/status
+func (h *ApplicationHandler) ChangeStatus(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	applicationID := c.Param("id")
+	if applicationID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "application id is required")
+	}
+
+	var input applicationsSvc.ChangeStatusInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	application, err := h.service.ChangeStatus(c.Request().Context(), userID, applicationID, input)
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "application not found")
+		}
+		if errors.Is(err, applicationsSvc.ErrInvalidStatus) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, applicationsSvc.ErrInvalidTransition) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to change application status")
+	}
+
+	return c.JSON(http.StatusOK, application)
+}
+
+// DeleteApplication handles DELETE /api/applications/:id
+func (h *ApplicationHandler) DeleteApplication(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "application service not available")
+	}
+
+	applicationID := c.Param("id")
+	if applicationID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "application id is required")
+	}
+
+	err = h.service.DeleteApplication(c.Request().Context(), userID, applicationID)
+	if err != nil {
+		if errors.Is(err, applicationsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "application not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete application")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
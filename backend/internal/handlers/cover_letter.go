@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"cv-gen/backend/internal/export"
 	appMiddleware "cv-gen/backend/internal/middleware"
 	coverletterSvc "cv-gen/backend/internal/services/coverletter"
 )
@@ -160,3 +161,65 @@ func (h *CoverLetterHandler) DeleteCoverLetter(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// ExportCoverLetterDOCX handles GET /api/cover-letters/:id/export.docx
+// The optional template_id query parameter selects the document style.
+func (h *CoverLetterHandler) ExportCoverLetterDOCX(c echo.Context) error {
+	return h.exportCoverLetter(c, docxContentType, "docx", func(title, content string) ([]byte, error) {
+		return export.CoverLetterDOCX(title, content, c.QueryParam("template_id"))
+	})
+}
+
+// ExportCoverLetterMarkdown handles GET /api/cover-letters/:id/export.md
+func (h *CoverLetterHandler) ExportCoverLetterMarkdown(c echo.Context) error {
+	return h.exportCoverLetter(c, markdownContentType, "md", func(title, content string) ([]byte, error) {
+		return export.CoverLetterMarkdown(title, content), nil
+	})
+}
+
+// exportCoverLetter loads the authenticated user's cover letter and sends it
+// as a file download rendered by render
+func (h *CoverLetterHandler) exportCoverLetter(c echo.Context, contentType, extension string, render func(title, content string) ([]byte, error)) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "cover letter service not available")
+	}
+
+	coverLetterID := c.Param("id")
+	if coverLetterID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "cover letter id is required")
+	}
+
+	coverLetter, err := h.service.GetCoverLetter(c.Request().Context(), userID, coverLetterID)
+	if err != nil {
+		if errors.Is(err, coverletterSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cover letter not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get cover letter")
+	}
+
+	title := coverLetterTitle(coverLetter)
+	data, err := render(title, coverLetter.Content)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to export cover letter")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, attachment(title, extension))
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// coverLetterTitle names a cover letter after the job it was written for
+func coverLetterTitle(coverLetter *coverletterSvc.CoverLetterResponse) string {
+	title := "Cover Letter"
+	if coverLetter.JobTitle != "" {
+		title += " - " + coverLetter.JobTitle
+	}
+	if coverLetter.CompanyName != "" {
+		title += " at " + coverLetter.CompanyName
+	}
+	return title
+}
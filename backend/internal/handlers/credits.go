@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
@@ -45,3 +46,33 @@ func (h *Handler) Credits(c echo.Context) error {
 		"remaining":                  totalRemaining,
 	})
 }
+
+// CreditsHistory returns the authenticated user's credit transactions
+// GET /api/credits/history
+func (h *Handler) CreditsHistory(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CreditsService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	// Parse pagination params
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	result, err := h.CreditsService.History(c.Request().Context(), userID, page, pageSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get credit history")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
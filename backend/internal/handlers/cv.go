@@ -88,7 +88,7 @@ func (h *Handler) CreateCV(c echo.Context) error {
 
 	cv, err := h.CVService.CreateCV(c.Request().Context(), userID, input)
 	if err != nil {
-		if errors.Is(err, cvSvc.ErrInvalidData) {
+		if errors.Is(err, cvSvc.ErrInvalidData) || errors.Is(err, cvSvc.ErrInvalidTemplate) {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create cv")
@@ -124,7 +124,7 @@ func (h *Handler) UpdateCV(c echo.Context) error {
 		if errors.Is(err, cvSvc.ErrNotFound) {
 			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
 		}
-		if errors.Is(err, cvSvc.ErrInvalidData) {
+		if errors.Is(err, cvSvc.ErrInvalidData) || errors.Is(err, cvSvc.ErrInvalidTemplate) {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update cv")
@@ -190,3 +190,62 @@ func (h *Handler) DuplicateCV(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, cv)
 }
+
+// DiffCV compares a CV against the user's master profile section by section
+// GET /api/cvs/:id/diff
+func (h *Handler) DiffCV(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	cvID := c.Param("id")
+	if cvID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "cv id is required")
+	}
+
+	diff, err := h.CVService.DiffWithProfile(c.Request().Context(), userID, cvID)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to diff cv")
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// KeywordMatch reports which job description keywords a CV covers
+// GET /api/cvs/:id/keyword-match
+func (h *Handler) KeywordMatch(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	cvID := c.Param("id")
+	if cvID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "cv id is required")
+	}
+
+	match, err := h.CVService.KeywordMatch(c.Request().Context(), userID, cvID)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		if errors.Is(err, cvSvc.ErrNoJobDescription) {
+			return echo.NewHTTPError(http.StatusBadRequest, "cv has no job description to match keywords against")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to match cv keywords")
+	}
+
+	return c.JSON(http.StatusOK, match)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+
+	"cv-gen/backend/internal/export"
+	appMiddleware "cv-gen/backend/internal/middleware"
+	cvSvc "cv-gen/backend/internal/services/cv"
+)
+
+// ExportCVPDF renders a CV as a PDF in its template's style
+// GET /api/cvs/:id/export.pdf
+func (h *Handler) ExportCVPDF(c echo.Context) error {
+	return h.exportCV(c, "application/pdf", "pdf", func(cv *cvSvc.CVResponse) ([]byte, error) {
+		return export.PDF(cv.CVData, cv.TemplateID)
+	})
+}
+
+// ExportCVDOCX renders a CV as a Word document in its template's style
+// GET /api/cvs/:id/export.docx
+func (h *Handler) ExportCVDOCX(c echo.Context) error {
+	return h.exportCV(c, docxContentType, "docx", func(cv *cvSvc.CVResponse) ([]byte, error) {
+		return export.DOCX(cv.CVData, cv.TemplateID)
+	})
+}
+
+// ExportCVMarkdown renders a CV as Markdown
+// GET /api/cvs/:id/export.md
+func (h *Handler) ExportCVMarkdown(c echo.Context) error {
+	return h.exportCV(c, markdownContentType, "md", func(cv *cvSvc.CVResponse) ([]byte, error) {
+		return export.Markdown(cv.CVData), nil
+	})
+}
+
+// ExportCVText renders a CV as plain text laid out for applicant tracking
+// systems
+// GET /api/cvs/:id/export.txt
+func (h *Handler) ExportCVText(c echo.Context) error {
+	return h.exportCV(c, textContentType, "txt", func(cv *cvSvc.CVResponse) ([]byte, error) {
+		return export.ATSText(cv.CVData), nil
+	})
+}
+
+// ExportCVLaTeX renders a CV as LaTeX source. The academic template gets a
+// publications-first layout; the others use moderncv.
+// GET /api/cvs/:id/export.tex
+func (h *Handler) ExportCVLaTeX(c echo.Context) error {
+	return h.exportCV(c, latexContentType, "tex", func(cv *cvSvc.CVResponse) ([]byte, error) {
+		return export.LaTeX(cv.CVData, cv.TemplateID), nil
+	})
+}
+
+// exportCV loads the authenticated user's CV and sends it as a file download
+// rendered by render
+func (h *Handler) exportCV(c echo.Context, contentType, extension string, render func(*cvSvc.CVResponse) ([]byte, error)) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	cvID := c.Param("id")
+	if cvID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "cv id is required")
+	}
+
+	cv, err := h.CVService.GetCV(c.Request().Context(), userID, cvID)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get cv")
+	}
+
+	data, err := render(cv)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to export cv")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, attachment(cv.Name, extension))
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// Media types of the text-based and Word exports
+const (
+	docxContentType     = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	markdownContentType = "text/markdown; charset=utf-8"
+	textContentType     = "text/plain; charset=utf-8"
+	latexContentType    = "application/x-tex; charset=utf-8"
+)
+
+// attachment builds a Content-Disposition header with a filesystem-safe
+// file name derived from name
+func attachment(name, extension string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)), r == '-', r == '_':
+			return r
+		case unicode.IsSpace(r):
+			return '_'
+		}
+		return -1
+	}, name)
+	if safe == "" {
+		safe = "cv"
+	}
+	return fmt.Sprintf("attachment; filename=%q", safe+"."+extension)
+}
@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"cv-gen/backend/internal/db"
+	creditsSvc "cv-gen/backend/internal/services/credits"
 	cvSvc "cv-gen/backend/internal/services/cv"
 	profileSvc "cv-gen/backend/internal/services/profile"
 )
@@ -12,20 +13,22 @@ type Handler struct {
 	Queries        *db.Queries
 	ProfileService *profileSvc.Service
 	CVService      *cvSvc.Service
+	CreditsService *creditsSvc.Service
 }
 
 // New creates a new Handler with the given dependencies
-func New(queries *db.Queries) *Handler {
+func New(pool db.TxBeginner, queries *db.Queries, creditsService *creditsSvc.Service) *Handler {
 	var profileService *profileSvc.Service
 	var cvService *cvSvc.Service
 	if queries != nil {
-		profileService = profileSvc.New(queries)
-		cvService = cvSvc.New(queries)
+		profileService = profileSvc.New(pool, queries)
+		cvService = cvSvc.New(pool, queries)
 	}
 
 	return &Handler{
 		Queries:        queries,
 		ProfileService: profileService,
 		CVService:      cvService,
+		CreditsService: creditsService,
 	}
 }
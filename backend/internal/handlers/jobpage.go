@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	"cv-gen/backend/internal/services/jobpage"
+)
+
+// JobPageHandler holds dependencies for importing jobs from their pages
+type JobPageHandler struct {
+	fetcher *jobpage.Fetcher
+}
+
+// NewJobPageHandler creates a new job page handler
+func NewJobPageHandler(fetcher *jobpage.Fetcher) *JobPageHandler {
+	return &JobPageHandler{
+		fetcher: fetcher,
+	}
+}
+
+// ParseJobURL handles POST /api/ai/parse-job-url
+// Reads a job posting from its page for the user to confirm before generating
+func (h *JobPageHandler) ParseJobURL(c echo.Context) error {
+	if _, err := appMiddleware.RequireUserID(c); err != nil {
+		return err
+	}
+
+	var req jobpage.Request
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if req.URL == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "url is required")
+	}
+
+	posting, err := h.fetcher.Fetch(c.Request().Context(), req.URL)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobpage.ErrInvalidURL):
+			return echo.NewHTTPError(http.StatusBadRequest, "url must be an http or https URL")
+		case errors.Is(err, jobpage.ErrUnavailable):
+			return echo.NewHTTPError(http.StatusBadGateway, "could not fetch the job page, please paste the job description instead")
+		case errors.Is(err, jobpage.ErrNoPosting):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "no job posting found on the page, please paste the job description instead")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to parse job page")
+	}
+
+	return c.JSON(http.StatusOK, posting)
+}
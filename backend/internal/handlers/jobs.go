@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	jobsSvc "cv-gen/backend/internal/services/jobs"
+)
+
+// JobHandler holds dependencies for saved job handlers
+type JobHandler struct {
+	service *jobsSvc.Service
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(service *jobsSvc.Service) *JobHandler {
+	return &JobHandler{
+		service: service,
+	}
+}
+
+// ListJobs handles GET /api/jobs
+func (h *JobHandler) ListJobs(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "job service not available")
+	}
+
+	jobs, err := h.service.ListJobs(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list jobs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"jobs": jobs,
+	})
+}
+
+// GetJob handles GET /api/jobs/:id
+func (h *JobHandler) GetJob(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "job service not available")
+	}
+
+	jobID := c.Param("id")
+	if jobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job id is required")
+	}
+
+	job, err := h.service.GetJob(c.Request().Context(), userID, jobID)
+	if err != nil {
+		if errors.Is(err, jobsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "job not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get job")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// CreateJob handles POST /api/jobs
+func (h *JobHandler) CreateJob(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "job service not available")
+	}
+
+	var input jobsSvc.CreateJobInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	job, err := h.service.CreateJob(c.Request().Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, jobsSvc.ErrInvalidData) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create job")
+	}
+
+	return c.JSON(http.StatusCreated, job)
+}
+
+// UpdateJob handles PUT /api/jobs/:id
+func (h *JobHandler) UpdateJob(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "job service not available")
+	}
+
+	jobID := c.Param("id")
+	if jobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job id is required")
+	}
+
+	var input jobsSvc.UpdateJobInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	job, err := h.service.UpdateJob(c.Request().Context(), userID, jobID, input)
+	if err != nil {
+		if errors.Is(err, jobsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "job not found")
+		}
+		if errors.Is(err, jobsSvc.ErrInvalidData) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update job")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// DeleteJob handles DELETE /api/jobs/:id
+func (h *JobHandler) DeleteJob(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "job service not available")
+	}
+
+	jobID := c.Param("id")
+	if jobID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "job id is required")
+	}
+
+	err = h.service.DeleteJob(c.Request().Context(), userID, jobID)
+	if err != nil {
+		if errors.Is(err, jobsSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "job not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete job")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	paymentsSvc "cv-gen/backend/internal/services/payments"
+)
+
+// maxWebhookBodySize bounds the webhook payload we are willing to read
+const maxWebhookBodySize = 64 * 1024
+
+// PaymentsHandler holds dependencies for payment webhook handlers
+type PaymentsHandler struct {
+	service *paymentsSvc.Service
+}
+
+// NewPaymentsHandler creates a new payments handler
+func NewPaymentsHandler(service *paymentsSvc.Service) *PaymentsHandler {
+	return &PaymentsHandler{
+		service: service,
+	}
+}
+
+// PaymentWebhook handles POST /api/webhooks/payments
+// The request is authenticated by its signature header, not by Clerk.
+func (h *PaymentsHandler) PaymentWebhook(c echo.Context) error {
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "payments service not available")
+	}
+
+	// The signature covers the raw body, so read it before any decoding
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+	}
+	if len(payload) > maxWebhookBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "payload too large")
+	}
+
+	result, err := h.service.HandleWebhook(c.Request().Context(), payload, c.Request().Header.Get(paymentsSvc.SignatureHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, paymentsSvc.ErrInvalidSignature):
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid signature")
+		case errors.Is(err, paymentsSvc.ErrInvalidPayload):
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid payload")
+		case errors.Is(err, paymentsSvc.ErrUnknownPackage), errors.Is(err, paymentsSvc.ErrMissingUser):
+			log.Printf("ERROR: cannot apply payment webhook: %v", err)
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+		log.Printf("ERROR: failed to process payment webhook: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to process webhook")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	profileSvc "cv-gen/backend/internal/services/profile"
+)
+
+// ListProfileVersions returns the version history of the authenticated user's profile
+// GET /api/profile/versions
+func (h *Handler) ListProfileVersions(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.ProfileService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	versions, err := h.ProfileService.ListVersions(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list profile versions")
+	}
+
+	return c.JSON(http.StatusOK, versions)
+}
+
+// GetProfileVersion returns a single version of the profile
+// GET /api/profile/versions/:version
+func (h *Handler) GetProfileVersion(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.ProfileService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	version, err := parseRevisionNumber(c.Param("version"), "version")
+	if err != nil {
+		return err
+	}
+
+	v, err := h.ProfileService.GetVersion(c.Request().Context(), userID, version)
+	if err != nil {
+		if errors.Is(err, profileSvc.ErrVersionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "profile version not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get profile version")
+	}
+
+	return c.JSON(http.StatusOK, v)
+}
+
+// DiffProfileVersions compares two versions of the profile section by section
+// GET /api/profile/versions/diff?from=:version&to=:version
+func (h *Handler) DiffProfileVersions(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.ProfileService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	from, err := parseRevisionNumber(c.QueryParam("from"), "from version")
+	if err != nil {
+		return err
+	}
+	to, err := parseRevisionNumber(c.QueryParam("to"), "to version")
+	if err != nil {
+		return err
+	}
+
+	diff, err := h.ProfileService.DiffVersions(c.Request().Context(), userID, from, to)
+	if err != nil {
+		if errors.Is(err, profileSvc.ErrVersionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "profile version not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to diff profile versions")
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// RestoreProfileVersion makes an earlier version the current profile
+// POST /api/profile/versions/:version/restore
+func (h *Handler) RestoreProfileVersion(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.ProfileService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	version, err := parseRevisionNumber(c.Param("version"), "version")
+	if err != nil {
+		return err
+	}
+
+	profile, err := h.ProfileService.RestoreVersion(c.Request().Context(), userID, version)
+	if err != nil {
+		if errors.Is(err, profileSvc.ErrVersionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "profile version not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to restore profile version")
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	cvSvc "cv-gen/backend/internal/services/cv"
+)
+
+// ListCVRevisions returns the revision history of a CV, newest first
+// GET /api/cvs/:id/revisions
+func (h *Handler) ListCVRevisions(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	revisions, err := h.CVService.ListRevisions(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list cv revisions")
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// GetCVRevision returns a single revision of a CV with its content
+// GET /api/cvs/:id/revisions/:rev
+func (h *Handler) GetCVRevision(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	revision, err := parseRevisionNumber(c.Param("rev"), "revision")
+	if err != nil {
+		return err
+	}
+
+	rev, err := h.CVService.GetRevision(c.Request().Context(), userID, c.Param("id"), revision)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrRevisionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "revision not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get cv revision")
+	}
+
+	return c.JSON(http.StatusOK, rev)
+}
+
+// RestoreCVRevision makes a revision the CV's current content
+// POST /api/cvs/:id/revisions/:rev/restore
+func (h *Handler) RestoreCVRevision(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	revision, err := parseRevisionNumber(c.Param("rev"), "revision")
+	if err != nil {
+		return err
+	}
+
+	cv, err := h.CVService.RestoreRevision(c.Request().Context(), userID, c.Param("id"), revision)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrRevisionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "revision not found")
+		}
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to restore cv revision")
+	}
+
+	return c.JSON(http.StatusOK, cv)
+}
+
+// parseRevisionNumber parses a 1-based revision or version number from a
+// request parameter
+func parseRevisionNumber(value, name string) (int32, error) {
+	number, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || number < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid "+name+" number")
+	}
+	return int32(number), nil
+}
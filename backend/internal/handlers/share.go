@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	shareSvc "cv-gen/backend/internal/services/share"
+	"cv-gen/backend/internal/themes"
+)
+
+// SharePasswordHeader carries the password of a protected share for API clients
+const SharePasswordHeader = "X-Share-Password"
+
+// ShareHandler holds dependencies for CV share link handlers
+type ShareHandler struct {
+	service *shareSvc.Service
+	// baseURL is prefixed to share paths to build absolute links; optional
+	baseURL string
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(service *shareSvc.Service, baseURL string) *ShareHandler {
+	return &ShareHandler{
+		service: service,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// SharesResponse lists the share links of a CV
+type SharesResponse struct {
+	Shares []shareSvc.ShareResponse `json:"shares"`
+}
+
+// CreateShare handles POST /api/cvs/:id/shares
+func (h *ShareHandler) CreateShare(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "share service not available")
+	}
+
+	var input shareSvc.CreateShareInput
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	share, err := h.service.Create(c.Request().Context(), userID, c.Param("id"), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, shareSvc.ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		case errors.Is(err, shareSvc.ErrInvalidExpiry), errors.Is(err, shareSvc.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create share")
+	}
+
+	h.withURL(share)
+	return c.JSON(http.StatusCreated, share)
+}
+
+// ListShares handles GET /api/cvs/:id/shares
+func (h *ShareHandler) ListShares(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "share service not available")
+	}
+
+	shares, err := h.service.List(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, shareSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list shares")
+	}
+
+	for i := range shares {
+		h.withURL(&shares[i])
+	}
+	return c.JSON(http.StatusOK, SharesResponse{Shares: shares})
+}
+
+// RevokeShare handles DELETE /api/cvs/:id/shares/:share_id
+func (h *ShareHandler) RevokeShare(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "share service not available")
+	}
+
+	share, err := h.service.Revoke(c.Request().Context(), userID, c.Param("id"), c.Param("share_id"))
+	if err != nil {
+		if errors.Is(err, shareSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "share not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to revoke share")
+	}
+
+	h.withURL(share)
+	return c.JSON(http.StatusOK, share)
+}
+
+// ViewShare handles GET and POST /s/:token
+// It is public: the token is the credential, plus the password for protected
+// shares, sent as a form field or in the X-Share-Password header. The CV is
+// rendered as HTML in its theme, or returned as JSON with ?format=json or an
+// Accept: application/json header.
+func (h *ShareHandler) ViewShare(c echo.Context) error {
+	if h.service == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "share service not available")
+	}
+
+	// Shared pages must not be indexed or cached by intermediaries
+	header := c.Response().Header()
+	header.Set("X-Robots-Tag", "noindex, nofollow")
+	header.Set(echo.HeaderCacheControl, "no-store")
+	header.Set("Referrer-Policy", "no-referrer")
+
+	wantsJSON := c.QueryParam("format") == "json" ||
+		strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON)
+
+	password := c.Request().Header.Get(SharePasswordHeader)
+	if c.Request().Method == http.MethodPost {
+		password = c.FormValue("password")
+	}
+
+	cv, err := h.service.Open(c.Request().Context(), c.Param("token"), password)
+	if err != nil {
+		status, message := shareErrorStatus(err)
+		if !wantsJSON && (errors.Is(err, shareSvc.ErrPasswordRequired) || errors.Is(err, shareSvc.ErrWrongPassword)) {
+			return renderPasswordForm(c, status, errors.Is(err, shareSvc.ErrWrongPassword))
+		}
+		return echo.NewHTTPError(status, message)
+	}
+
+	if wantsJSON {
+		return c.JSON(http.StatusOK, cv)
+	}
+
+	templateID := cv.TemplateID
+	if !themes.Valid(templateID) {
+		templateID = themes.DefaultID
+	}
+
+	var buf bytes.Buffer
+	if err := themes.Render(&buf, templateID, cv.CVData); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render cv")
+	}
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// withURL sets the absolute link of a share when a base URL is configured
+func (h *ShareHandler) withURL(share *shareSvc.ShareResponse) {
+	if h.baseURL != "" {
+		share.URL = h.baseURL + share.Path
+	}
+}
+
+// shareErrorStatus maps errors from opening a share to HTTP responses
+func shareErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, shareSvc.ErrNotFound):
+		return http.StatusNotFound, "share not found"
+	case errors.Is(err, shareSvc.ErrRevoked), errors.Is(err, shareSvc.ErrExpired):
+		return http.StatusGone, err.Error()
+	case errors.Is(err, shareSvc.ErrPasswordRequired):
+		return http.StatusUnauthorized, err.Error()
+	case errors.Is(err, shareSvc.ErrWrongPassword):
+		return http.StatusForbidden, err.Error()
+	}
+	return http.StatusInternalServerError, "failed to open share"
+}
+
+// passwordFormTemplate asks a visitor for the password of a protected share
+// and posts it back to the same URL
+var passwordFormTemplate = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex, nofollow">
+<title>Password required</title>
+<style>
+body { margin: 0; min-height: 100vh; display: flex; align-items: center; justify-content: center; background: #FFFBF5; color: #1A1A1A; font-family: ui-sans-serif, system-ui, sans-serif; }
+form { background: #fff; border: 1px solid #E5E0D8; border-radius: 0.5rem; padding: 2rem; width: 20rem; }
+h1 { font-size: 1.125rem; margin: 0 0 1rem; }
+p { color: #FF6B4A; font-size: 0.875rem; margin: 0 0 1rem; }
+input { box-sizing: border-box; width: 100%; padding: 0.5rem; border: 1px solid #E5E0D8; border-radius: 0.25rem; margin-bottom: 1rem; font: inherit; }
+button { width: 100%; padding: 0.5rem; border: 0; border-radius: 0.25rem; background: #F5A623; color: #1A1A1A; font: inherit; font-weight: 600; cursor: pointer; }
+</style>
+</head>
+<body>
+<form method="post">
+<h1>This CV is password protected</h1>
+{{if .}}<p>That password is not correct.</p>{{end}}
+<input type="password" name="password" placeholder="Password" autofocus required>
+<button type="submit">View CV</button>
+</form>
+</body>
+</html>
+`))
+
+func renderPasswordForm(c echo.Context, status int, wrongPassword bool) error {
+	var buf bytes.Buffer
+	if err := passwordFormTemplate.Execute(&buf, wrongPassword); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render page")
+	}
+	return c.HTMLBlob(status, buf.Bytes())
+}
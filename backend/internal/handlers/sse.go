@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"cv-gen/backend/internal/services/ai"
+)
+
+// sseWriter writes Server-Sent Events to an Echo response
+type sseWriter struct {
+	c echo.Context
+}
+
+// newSSEWriter sets the event-stream headers and starts the response
+func newSSEWriter(c echo.Context) *sseWriter {
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, "text/event-stream")
+	header.Set(echo.HeaderCacheControl, "no-cache")
+	header.Set(echo.HeaderConnection, "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+
+	return &sseWriter{c: c}
+}
+
+// Send writes a single named event with a JSON payload and flushes it
+func (w *sseWriter) Send(event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w.c.Response(), "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	w.c.Response().Flush()
+
+	// Stop generating if the client went away
+	return w.c.Request().Context().Err()
+}
+
+// SendError writes an error event carrying the HTTP status and message
+func (w *sseWriter) SendError(err *echo.HTTPError) error {
+	return w.Send(ai.EventError, map[string]interface{}{
+		"status":  err.Code,
+		"message": fmt.Sprint(err.Message),
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	appMiddleware "cv-gen/backend/internal/middleware"
+	cvSvc "cv-gen/backend/internal/services/cv"
+	"cv-gen/backend/internal/themes"
+)
+
+// ThemesResponse lists the available CV themes
+type ThemesResponse struct {
+	Themes    []themes.Theme `json:"themes"`
+	DefaultID string         `json:"default_id"`
+}
+
+// ListThemes returns the registered CV themes
+// GET /api/themes
+func (h *Handler) ListThemes(c echo.Context) error {
+	return c.JSON(http.StatusOK, ThemesResponse{
+		Themes:    themes.List(),
+		DefaultID: themes.DefaultID,
+	})
+}
+
+// RenderCVHTML renders a CV as a standalone HTML page using the same markup as
+// the frontend theme. The optional template_id query parameter previews the CV
+// in another theme.
+// GET /api/cvs/:id/render.html
+func (h *Handler) RenderCVHTML(c echo.Context) error {
+	userID, err := appMiddleware.RequireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.CVService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not connected")
+	}
+
+	cvID := c.Param("id")
+	if cvID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "cv id is required")
+	}
+
+	templateID := c.QueryParam("template_id")
+	if templateID != "" && !themes.Valid(templateID) {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid template id")
+	}
+
+	cv, err := h.CVService.GetCV(c.Request().Context(), userID, cvID)
+	if err != nil {
+		if errors.Is(err, cvSvc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "cv not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get cv")
+	}
+
+	if templateID == "" {
+		templateID = cv.TemplateID
+	}
+	// CVs saved before template IDs were validated may name unknown themes
+	if !themes.Valid(templateID) {
+		templateID = themes.DefaultID
+	}
+
+	var buf bytes.Buffer
+	if err := themes.Render(&buf, templateID, cv.CVData); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render cv")
+	}
+
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
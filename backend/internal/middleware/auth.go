@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -13,8 +14,29 @@ import (
 const (
 	// UserIDKey is the key used to store the user ID in the Echo context
 	UserIDKey = "user_id"
+	// UserRoleKey is the key used to store the user's role claim in the Echo context
+	UserRoleKey = "user_role"
+	// AdminRole is the role claim value that grants access to the admin API
+	AdminRole = "admin"
 )
 
+// roleClaims holds the custom session token claims that carry a user's role.
+// Clerk session tokens can expose public metadata with a claim such as
+// {"metadata": "{{user.public_metadata}}"}, or the role directly.
+type roleClaims struct {
+	Role     string `json:"role"`
+	Metadata struct {
+		Role string `json:"role"`
+	} `json:"metadata"`
+}
+
+func (r *roleClaims) role() string {
+	if r.Role != "" {
+		return r.Role
+	}
+	return r.Metadata.Role
+}
+
 // ClerkAuth returns an Echo middleware that validates Clerk JWT tokens
 func ClerkAuth() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -34,6 +56,9 @@ func ClerkAuth() echo.MiddlewareFunc {
 			// Verify the JWT token
 			claims, err := jwt.Verify(c.Request().Context(), &jwt.VerifyParams{
 				Token: token,
+				CustomClaimsConstructor: func(_ context.Context) any {
+					return &roleClaims{}
+				},
 			})
 			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
@@ -41,6 +66,9 @@ func ClerkAuth() echo.MiddlewareFunc {
 
 			// Store the user ID in the context
 			c.Set(UserIDKey, claims.Subject)
+			if custom, ok := claims.Custom.(*roleClaims); ok {
+				c.Set(UserRoleKey, custom.role())
+			}
 
 			return next(c)
 		}
@@ -67,6 +95,41 @@ func RequireUserID(c echo.Context) (string, error) {
 	return userID, nil
 }
 
+// GetUserRole extracts the user's role claim from the Echo context
+// Returns empty string if not found
+func GetUserRole(c echo.Context) string {
+	role, ok := c.Get(UserRoleKey).(string)
+	if !ok {
+		return ""
+	}
+	return role
+}
+
+// RequireAdmin returns an Echo middleware that only lets through users with
+// the admin role claim or whose user ID is in adminUserIDs. It must run after
+// ClerkAuth.
+func RequireAdmin(adminUserIDs []string) echo.MiddlewareFunc {
+	allowed := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		allowed[id] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, err := RequireUserID(c)
+			if err != nil {
+				return err
+			}
+
+			if GetUserRole(c) != AdminRole && !allowed[userID] {
+				return echo.NewHTTPError(http.StatusForbidden, "admin access required")
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // InitClerk initializes the Clerk SDK with the secret key
 func InitClerk(secretKey string) {
 	clerk.SetKey(secretKey)
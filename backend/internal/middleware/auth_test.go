@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		role       string
+		wantStatus int
+	}{
+		{"admin role", "user_1", AdminRole, http.StatusOK},
+		{"allow-listed user", "user_ops", "", http.StatusOK},
+		{"regular user", "user_2", "member", http.StatusForbidden},
+		{"unauthenticated", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/admin/top-users", nil), httptest.NewRecorder())
+			if tt.userID != "" {
+				c.Set(UserIDKey, tt.userID)
+			}
+			if tt.role != "" {
+				c.Set(UserRoleKey, tt.role)
+			}
+
+			handler := RequireAdmin([]string{"user_ops"})(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			status := http.StatusOK
+			if err := handler(c); err != nil {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) {
+					t.Fatalf("expected HTTP error, got %v", err)
+				}
+				status = httpErr.Code
+			}
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,567 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Item changes reported by DiffResumes
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+	ChangeMoved    = "moved"
+)
+
+// Word operations reported in word-level diffs
+const (
+	WordEqual  = "equal"
+	WordInsert = "insert"
+	WordDelete = "delete"
+)
+
+// maxLCSCells bounds the size of the table used to align two sequences;
+// larger inputs are reported as a wholesale replacement
+const maxLCSCells = 250000
+
+// rewordThreshold is the word overlap above which a removed and an added
+// string are reported as one string being reworded
+const rewordThreshold = 0.5
+
+// sectionKeys lists the fields that identify an item of each list section,
+// most significant first. Items are matched on all of them, then on the
+// first alone, so a retitled position still matches its employer.
+var sectionKeys = map[string][]string{
+	"work":         {"name", "position"},
+	"volunteer":    {"organization", "position"},
+	"education":    {"institution", "studyType", "area"},
+	"awards":       {"title", "awarder"},
+	"certificates": {"name", "issuer"},
+	"publications": {"name", "publisher"},
+	"skills":       {"name"},
+	"languages":    {"language"},
+	"interests":    {"name"},
+	"references":   {"name"},
+	"projects":     {"name"},
+}
+
+// ResumeDiff describes how one resume differs from another, section by section
+type ResumeDiff struct {
+	Sections []SectionDiff `json:"sections"`
+}
+
+// SectionDiff describes the changes to one section. Basics reports its
+// fields; list sections report their items.
+type SectionDiff struct {
+	Section string      `json:"section"`
+	Fields  []FieldDiff `json:"fields,omitempty"`
+	Items   []ItemDiff  `json:"items,omitempty"`
+}
+
+// ItemDiff describes an item of a list section that was added, removed,
+// modified or moved. A modified item may also have moved.
+type ItemDiff struct {
+	Label       string          `json:"label"`
+	Change      string          `json:"change"`
+	Moved       bool            `json:"moved,omitempty"`
+	BeforeIndex *int            `json:"before_index,omitempty"`
+	AfterIndex  *int            `json:"after_index,omitempty"`
+	Fields      []FieldDiff     `json:"fields,omitempty"`
+	Item        json.RawMessage `json:"item,omitempty"`
+}
+
+// FieldDiff describes a changed field. Text fields report both values and a
+// word-level diff; list fields such as highlights report the strings that
+// were added, removed, reworded or moved.
+type FieldDiff struct {
+	Field    string       `json:"field"`
+	Before   string       `json:"before,omitempty"`
+	After    string       `json:"after,omitempty"`
+	Words    []WordChange `json:"words,omitempty"`
+	Added    []string     `json:"added,omitempty"`
+	Removed  []string     `json:"removed,omitempty"`
+	Reworded []Rewording  `json:"reworded,omitempty"`
+	Moved    []string     `json:"moved,omitempty"`
+}
+
+// WordChange is a run of words that is unchanged, inserted or deleted
+type WordChange struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Rewording pairs a string with the reworded string that replaced it
+type Rewording struct {
+	Before string       `json:"before"`
+	After  string       `json:"after"`
+	Words  []WordChange `json:"words"`
+}
+
+// DiffResumes compares two resumes section by section. Only sections with
+// changes are reported, in ValidSections order.
+func DiffResumes(before, after *JSONResume) *ResumeDiff {
+	beforeSections, afterSections := before.SectionsJSON(), after.SectionsJSON()
+
+	diff := &ResumeDiff{Sections: []SectionDiff{}}
+	for _, section := range ChangedSections(before, after) {
+		sd := SectionDiff{Section: section}
+		if section == "basics" {
+			sd.Fields = diffFields(flattenObject(beforeSections[section]), flattenObject(afterSections[section]))
+		} else {
+			sd.Items = diffItems(section, splitItems(beforeSections[section]), splitItems(afterSections[section]))
+		}
+		if len(sd.Fields) > 0 || len(sd.Items) > 0 {
+			diff.Sections = append(diff.Sections, sd)
+		}
+	}
+	return diff
+}
+
+// DiffWords returns a word-level diff between two strings
+func DiffWords(before, after string) []WordChange {
+	changes := []WordChange{}
+	for _, op := range alignSequences(strings.Fields(before), strings.Fields(after)) {
+		if n := len(changes); n > 0 && changes[n-1].Op == op.kind {
+			changes[n-1].Text += " " + op.text
+			continue
+		}
+		changes = append(changes, WordChange{Op: op.kind, Text: op.text})
+	}
+	return changes
+}
+
+// fieldValue is a flattened field: either text or a list of strings
+type fieldValue struct {
+	text   string
+	list   []string
+	isList bool
+}
+
+// item is one entry of a list section
+type item struct {
+	raw    json.RawMessage
+	fields map[string]fieldValue
+}
+
+func splitItems(data json.RawMessage) []item {
+	var raws []json.RawMessage
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &raws)
+	}
+	items := make([]item, 0, len(raws))
+	for _, raw := range raws {
+		items = append(items, item{raw: raw, fields: flattenObject(raw)})
+	}
+	return items
+}
+
+// flattenObject flattens a JSON object into text and string list fields.
+// Nested objects use dotted paths and lists of objects render each object
+// as one string.
+func flattenObject(data json.RawMessage) map[string]fieldValue {
+	fields := map[string]fieldValue{}
+	var value interface{}
+	if len(data) > 0 && json.Unmarshal(data, &value) == nil {
+		flattenValue("", value, fields)
+	}
+	return fields
+}
+
+func flattenValue(path string, value interface{}, fields map[string]fieldValue) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path != "" {
+				key = path + "." + key
+			}
+			flattenValue(key, child, fields)
+		}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, element := range v {
+			list = append(list, scalarText(element))
+		}
+		fields[path] = fieldValue{list: list, isList: true}
+	case nil:
+	default:
+		fields[path] = fieldValue{text: scalarText(v)}
+	}
+}
+
+// scalarText renders a list element as a string; objects join their values
+// in key order
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if text := scalarText(v[key]); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, " | ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// diffFields compares flattened fields in name order
+func diffFields(before, after map[string]fieldValue) []FieldDiff {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := []FieldDiff{}
+	for _, name := range names {
+		b, a := before[name], after[name]
+		if b.isList || a.isList {
+			if fd, changed := diffList(name, b.list, a.list); changed {
+				diffs = append(diffs, fd)
+			}
+			continue
+		}
+		if b.text == a.text {
+			continue
+		}
+		fd := FieldDiff{Field: name, Before: b.text, After: a.text}
+		if b.text != "" && a.text != "" {
+			fd.Words = DiffWords(b.text, a.text)
+		}
+		diffs = append(diffs, fd)
+	}
+	return diffs
+}
+
+// diffList compares two string lists. Strings kept in place are unchanged;
+// strings present in both but out of place moved; the remaining removed and
+// added strings are paired up as rewordings when they share enough words.
+func diffList(name string, before, after []string) (FieldDiff, bool) {
+	var removed, added []string
+	for _, op := range alignSequences(before, after) {
+		switch op.kind {
+		case WordDelete:
+			removed = append(removed, op.text)
+		case WordInsert:
+			added = append(added, op.text)
+		}
+	}
+
+	fd := FieldDiff{Field: name}
+
+	// Strings both removed and added were moved
+	var remaining []string
+	for _, s := range added {
+		if i := indexOf(removed, s); i >= 0 {
+			fd.Moved = append(fd.Moved, s)
+			removed = append(removed[:i], removed[i+1:]...)
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	added = remaining
+
+	// Pair each removed string with the most similar added one
+	used := make([]bool, len(added))
+	for _, r := range removed {
+		best, bestScore := -1, rewordThreshold
+		for i, a := range added {
+			if used[i] {
+				continue
+			}
+			if score := wordOverlap(r, a); score >= bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			fd.Removed = append(fd.Removed, r)
+			continue
+		}
+		used[best] = true
+		fd.Reworded = append(fd.Reworded, Rewording{Before: r, After: added[best], Words: DiffWords(r, added[best])})
+	}
+	for i, a := range added {
+		if !used[i] {
+			fd.Added = append(fd.Added, a)
+		}
+	}
+
+	changed := len(fd.Added) > 0 || len(fd.Removed) > 0 || len(fd.Reworded) > 0 || len(fd.Moved) > 0
+	return fd, changed
+}
+
+// diffItems matches the items of a list section by their identifying fields
+// and reports the added, removed, modified and moved ones. Items are listed
+// in their order in after, followed by the removed items.
+func diffItems(section string, before, after []item) []ItemDiff {
+	keys := sectionKeys[section]
+	match := matchItems(keys, before, after)
+	matched := make([]bool, len(before))
+	for _, bi := range match {
+		if bi >= 0 {
+			matched[bi] = true
+		}
+	}
+
+	moved := movedItems(match)
+
+	diffs := []ItemDiff{}
+	for ai, a := range after {
+		afterIndex := ai
+		bi := match[ai]
+		if bi < 0 {
+			diffs = append(diffs, ItemDiff{
+				Label:      itemLabel(a, keys),
+				Change:     ChangeAdded,
+				AfterIndex: &afterIndex,
+				Item:       a.raw,
+			})
+			continue
+		}
+
+		beforeIndex := bi
+		fields := diffFields(before[bi].fields, a.fields)
+		if len(fields) == 0 && !moved[ai] {
+			continue
+		}
+		d := ItemDiff{
+			Label:       itemLabel(a, keys),
+			Change:      ChangeModified,
+			Moved:       moved[ai],
+			BeforeIndex: &beforeIndex,
+			AfterIndex:  &afterIndex,
+			Fields:      fields,
+		}
+		if len(fields) == 0 {
+			d.Change = ChangeMoved
+		}
+		diffs = append(diffs, d)
+	}
+
+	for bi, b := range before {
+		if matched[bi] {
+			continue
+		}
+		beforeIndex := bi
+		diffs = append(diffs, ItemDiff{
+			Label:       itemLabel(b, keys),
+			Change:      ChangeRemoved,
+			BeforeIndex: &beforeIndex,
+			Item:        b.raw,
+		})
+	}
+	return diffs
+}
+
+// matchItems returns the index in before of each after item, or -1 when it
+// has no counterpart
+func matchItems(keys []string, before, after []item) []int {
+	match := make([]int, len(after))
+	matched := make([]bool, len(before))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Match on all identifying fields first, then on the most significant one
+	for _, n := range []int{len(keys), 1} {
+		for ai, a := range after {
+			if match[ai] >= 0 {
+				continue
+			}
+			key := itemKey(a, keys[:n])
+			if key == "" {
+				continue
+			}
+			for bi, b := range before {
+				if !matched[bi] && itemKey(b, keys[:n]) == key {
+					match[ai], matched[bi] = bi, true
+					break
+				}
+			}
+		}
+	}
+	return match
+}
+
+// movedItems reports which matched items changed their relative order. The
+// longest run of matched items that kept their order stays in place; every
+// other matched item moved.
+func movedItems(match []int) []bool {
+	var positions, sequence []int
+	for ai, bi := range match {
+		if bi >= 0 {
+			positions = append(positions, ai)
+			sequence = append(sequence, bi)
+		}
+	}
+
+	moved := make([]bool, len(match))
+	for _, ai := range positions {
+		moved[ai] = true
+	}
+	for _, i := range longestIncreasing(sequence) {
+		moved[positions[i]] = false
+	}
+	return moved
+}
+
+// longestIncreasing returns the indexes of a longest strictly increasing
+// subsequence of values
+func longestIncreasing(values []int) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	lengths := make([]int, len(values))
+	prev := make([]int, len(values))
+	best := 0
+	for i := range values {
+		lengths[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && lengths[j]+1 > lengths[i] {
+				lengths[i], prev[i] = lengths[j]+1, j
+			}
+		}
+		if lengths[i] > lengths[best] {
+			best = i
+		}
+	}
+
+	indexes := make([]int, lengths[best])
+	for i, k := best, len(indexes)-1; i >= 0; i, k = prev[i], k-1 {
+		indexes[k] = i
+	}
+	return indexes
+}
+
+func itemKey(it item, keys []string) string {
+	parts := make([]string, 0, len(keys))
+	nonEmpty := false
+	for _, key := range keys {
+		value := strings.ToLower(strings.TrimSpace(it.fields[key].text))
+		nonEmpty = nonEmpty || value != ""
+		parts = append(parts, value)
+	}
+	if !nonEmpty {
+		return ""
+	}
+	return strings.Join(parts, "\x00")
+}
+
+func itemLabel(it item, keys []string) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if value := strings.TrimSpace(it.fields[key].text); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// alignOp is one step of an alignment between two sequences
+type alignOp struct {
+	kind string
+	text string
+}
+
+// alignSequences aligns two sequences on their longest common subsequence
+func alignSequences(a, b []string) []alignOp {
+	ops := make([]alignOp, 0, len(a)+len(b))
+	if len(a)*len(b) > maxLCSCells {
+		for _, s := range a {
+			ops = append(ops, alignOp{WordDelete, s})
+		}
+		for _, s := range b {
+			ops = append(ops, alignOp{WordInsert, s})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, alignOp{WordEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, alignOp{WordDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, alignOp{WordInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, alignOp{WordDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, alignOp{WordInsert, b[j]})
+	}
+	return ops
+}
+
+// wordOverlap returns the Jaccard similarity of the words of two strings
+func wordOverlap(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(strings.ToLower(s)) {
+		if w = strings.Trim(w, ".,;:!?()\"'"); w != "" {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func findSection(t *testing.T, diff *ResumeDiff, name string) SectionDiff {
+	t.Helper()
+	for _, s := range diff.Sections {
+		if s.Section == name {
+			return s
+		}
+	}
+	t.Fatalf("section %q not in diff %+v", name, diff.Sections)
+	return SectionDiff{}
+}
+
+func TestDiffResumesIdentical(t *testing.T) {
+	resume := &JSONResume{Work: []Work{{Name: "Acme", Position: "Engineer"}}}
+	if diff := DiffResumes(resume, resume); len(diff.Sections) != 0 {
+		t.Errorf("expected no changes, got %+v", diff.Sections)
+	}
+}
+
+func TestDiffResumesBasics(t *testing.T) {
+	before := &JSONResume{Basics: &Basics{
+		Name:     "Ada Lovelace",
+		Email:    "ada@example.com",
+		Summary:  "A mathematician who wrote the first program.",
+		Location: &Location{City: "London"},
+	}}
+	after := &JSONResume{Basics: &Basics{
+		Name:     "Ada Lovelace",
+		Email:    "ada@example.com",
+		Summary:  "A pioneering mathematician who wrote the first published program.",
+		Location: &Location{City: "Paris"},
+	}}
+
+	basics := findSection(t, DiffResumes(before, after), "basics")
+	if len(basics.Fields) != 2 {
+		t.Fatalf("expected 2 changed fields, got %+v", basics.Fields)
+	}
+
+	city := basics.Fields[0]
+	if city.Field != "location.city" || city.Before != "London" || city.After != "Paris" {
+		t.Errorf("unexpected location change %+v", city)
+	}
+
+	summary := basics.Fields[1]
+	want := []WordChange{
+		{WordEqual, "A"},
+		{WordInsert, "pioneering"},
+		{WordEqual, "mathematician who wrote the first"},
+		{WordInsert, "published"},
+		{WordEqual, "program."},
+	}
+	if summary.Field != "summary" || !reflect.DeepEqual(summary.Words, want) {
+		t.Errorf("unexpected summary diff %+v", summary)
+	}
+}
+
+func TestDiffResumesWorkItems(t *testing.T) {
+	before := &JSONResume{Work: []Work{
+		{Name: "Acme", Position: "Engineer", Highlights: []string{
+			"Built the billing system",
+			"Mentored two interns",
+			"Cut deploy time in half",
+			"Wrote the on-call runbook",
+		}},
+		{Name: "Globex", Position: "Intern"},
+		{Name: "Initech", Position: "Analyst"},
+	}}
+	after := &JSONResume{Work: []Work{
+		{Name: "Globex", Position: "Intern"},
+		{Name: "Acme", Position: "Senior Engineer", Highlights: []string{
+			"Wrote the on-call runbook",
+			"Cut deploy time in half",
+			"Built the billing system in Go",
+			"Led the payments migration",
+		}},
+		{Name: "Umbrella", Position: "Consultant"},
+	}}
+
+	work := findSection(t, DiffResumes(before, after), "work")
+	if len(work.Items) != 3 {
+		t.Fatalf("expected 3 item changes, got %+v", work.Items)
+	}
+
+	acme := work.Items[0]
+	if acme.Label != "Acme · Senior Engineer" || acme.Change != ChangeModified || !acme.Moved {
+		t.Fatalf("unexpected Acme change %+v", acme)
+	}
+	if *acme.BeforeIndex != 0 || *acme.AfterIndex != 1 {
+		t.Errorf("unexpected Acme indexes %d -> %d", *acme.BeforeIndex, *acme.AfterIndex)
+	}
+
+	var highlights, position FieldDiff
+	for _, f := range acme.Fields {
+		switch f.Field {
+		case "highlights":
+			highlights = f
+		case "position":
+			position = f
+		}
+	}
+	if position.Before != "Engineer" || position.After != "Senior Engineer" {
+		t.Errorf("unexpected position change %+v", position)
+	}
+	// The runbook and deploy highlights swapped places; one of them moved
+	if len(highlights.Moved) != 1 {
+		t.Errorf("unexpected moved highlights %v", highlights.Moved)
+	}
+	if len(highlights.Reworded) != 1 || highlights.Reworded[0].After != "Built the billing system in Go" {
+		t.Errorf("unexpected reworded highlights %+v", highlights.Reworded)
+	}
+	if !reflect.DeepEqual(highlights.Added, []string{"Led the payments migration"}) {
+		t.Errorf("unexpected added highlights %v", highlights.Added)
+	}
+	if !reflect.DeepEqual(highlights.Removed, []string{"Mentored two interns"}) {
+		t.Errorf("unexpected removed highlights %v", highlights.Removed)
+	}
+
+	if added := work.Items[1]; added.Change != ChangeAdded || added.Label != "Umbrella · Consultant" || added.Item == nil {
+		t.Errorf("unexpected added item %+v", added)
+	}
+	if removed := work.Items[2]; removed.Change != ChangeRemoved || removed.Label != "Initech · Analyst" || *removed.BeforeIndex != 2 {
+		t.Errorf("unexpected removed item %+v", removed)
+	}
+}
+
+func TestDiffResumesReorderedSkills(t *testing.T) {
+	before := &JSONResume{Skills: []Skill{{Name: "Go"}, {Name: "SQL"}, {Name: "Docker"}}}
+	after := &JSONResume{Skills: []Skill{{Name: "Docker"}, {Name: "Go"}, {Name: "SQL"}}}
+
+	skills := findSection(t, DiffResumes(before, after), "skills")
+	if len(skills.Items) != 1 {
+		t.Fatalf("expected only the moved skill, got %+v", skills.Items)
+	}
+	if item := skills.Items[0]; item.Label != "Docker" || item.Change != ChangeMoved {
+		t.Errorf("unexpected skill change %+v", item)
+	}
+}
+
+func TestDiffWords(t *testing.T) {
+	got := DiffWords("Led a team of five", "Led a team of eight engineers")
+	want := []WordChange{
+		{WordEqual, "Led a team of"},
+		{WordDelete, "five"},
+		{WordInsert, "eight engineers"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffWords() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidFieldPath is returned when a field path cannot be parsed
+	ErrInvalidFieldPath = errors.New("invalid field path")
+	// ErrFieldNotFound is returned when a field path selects nothing in a resume
+	ErrFieldNotFound = errors.New("field not found")
+)
+
+// FieldPath locates content in a JSON Resume: a whole section, an item of a
+// list section, or a dotted field of either, as in "basics.summary",
+// "projects[1]" or "work[0].highlights"
+type FieldPath struct {
+	Section string
+	// Index selects an item of a list section; -1 when no item is selected
+	Index int
+	// Field is a dotted path inside the section or item; empty selects all of it
+	Field string
+}
+
+// ParseFieldPath parses a path such as "basics.summary" or "projects[1]"
+func ParseFieldPath(path string) (FieldPath, error) {
+	invalid := func(reason string) (FieldPath, error) {
+		return FieldPath{}, fmt.Errorf("%w %q: %s", ErrInvalidFieldPath, path, reason)
+	}
+
+	rest := strings.TrimSpace(path)
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+	p := FieldPath{Section: rest[:end], Index: -1}
+	rest = rest[end:]
+
+	if !IsValidSection(p.Section) {
+		return invalid("unknown section")
+	}
+	_, isList := sectionKeys[p.Section]
+
+	if strings.HasPrefix(rest, "[") {
+		if !isList {
+			return invalid(p.Section + " is not a list")
+		}
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return invalid("missing ]")
+		}
+		index, err := strconv.Atoi(rest[1:end])
+		if err != nil || index < 0 {
+			return invalid("index must be a non-negative number")
+		}
+		p.Index = index
+		rest = rest[end+1:]
+	}
+
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") {
+			return invalid("expected . after ]")
+		}
+		if isList && p.Index < 0 {
+			return invalid("a field of " + p.Section + " needs an item index")
+		}
+		p.Field = rest[1:]
+		for _, key := range strings.Split(p.Field, ".") {
+			if key == "" || strings.ContainsAny(key, "[]") {
+				return invalid("fields are dotted names")
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// String returns the path in the form ParseFieldPath accepts
+func (p FieldPath) String() string {
+	s := p.Section
+	if p.Index >= 0 {
+		s += "[" + strconv.Itoa(p.Index) + "]"
+	}
+	if p.Field != "" {
+		s += "." + p.Field
+	}
+	return s
+}
+
+// HasField reports whether the path selects content in the resume
+func HasField(r *JSONResume, p FieldPath) bool {
+	_, ok := lookupField(resumeObject(r), p)
+	return ok
+}
+
+// RestoreFields returns a copy of target in which the content at each path is
+// replaced with the content at the same path in source. Items of list
+// sections are found in target the way DiffResumes matches them, so a
+// restored item keeps the position it has in target; an item target dropped
+// is appended to its section.
+func RestoreFields(source, target *JSONResume, paths []FieldPath) (*JSONResume, error) {
+	src, dst := resumeObject(source), resumeObject(target)
+
+	for _, p := range paths {
+		value, ok := lookupField(src, p)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, p)
+		}
+
+		if p.Index < 0 {
+			section, _ := dst[p.Section].(map[string]interface{})
+			dst[p.Section] = setValue(section, p.Field, value)
+			continue
+		}
+
+		items, _ := dst[p.Section].([]interface{})
+		at := matchingItem(p.Section, src[p.Section], items, p.Index)
+		switch {
+		case at < 0:
+			// Put the whole item back when it is missing, even if only one of its fields was locked
+			items = append(items, src[p.Section].([]interface{})[p.Index])
+		case p.Field == "":
+			items[at] = value
+		default:
+			item, _ := items[at].(map[string]interface{})
+			items[at] = setValue(item, p.Field, value)
+		}
+		dst[p.Section] = items
+	}
+
+	data, err := json.Marshal(dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resume: %w", err)
+	}
+	var restored JSONResume
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return nil, fmt.Errorf("failed to decode resume: %w", err)
+	}
+	return &restored, nil
+}
+
+// SelectSections returns a copy of the resume with only the given sections
+func SelectSections(r *JSONResume, sections []string) *JSONResume {
+	selected := map[string]json.RawMessage{}
+	for section, data := range r.SectionsJSON() {
+		if indexOf(sections, section) >= 0 {
+			selected[section] = data
+		}
+	}
+
+	var resume JSONResume
+	if data, err := json.Marshal(selected); err == nil {
+		_ = json.Unmarshal(data, &resume)
+	}
+	return &resume
+}
+
+// resumeObject decodes a resume into generic JSON values keyed by section
+func resumeObject(r *JSONResume) map[string]interface{} {
+	object := map[string]interface{}{}
+	if r == nil {
+		return object
+	}
+	if data, err := json.Marshal(r); err == nil {
+		_ = json.Unmarshal(data, &object)
+	}
+	return object
+}
+
+func lookupField(object map[string]interface{}, p FieldPath) (interface{}, bool) {
+	value, ok := object[p.Section]
+	if !ok {
+		return nil, false
+	}
+	if p.Index >= 0 {
+		items, _ := value.([]interface{})
+		if p.Index >= len(items) {
+			return nil, false
+		}
+		value = items[p.Index]
+	}
+	if p.Field == "" {
+		return value, true
+	}
+
+	for _, key := range strings.Split(p.Field, ".") {
+		fields, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil, false
+		}
+		if value, ok = fields[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// setValue sets a dotted field in an object, creating the object and any
+// objects along the path as needed. An empty field replaces the object.
+func setValue(object map[string]interface{}, field string, value interface{}) interface{} {
+	if field == "" {
+		return value
+	}
+	if object == nil {
+		object = map[string]interface{}{}
+	}
+
+	key, rest, _ := strings.Cut(field, ".")
+	if rest == "" {
+		object[key] = value
+		return object
+	}
+	child, _ := object[key].(map[string]interface{})
+	object[key] = setValue(child, rest, value)
+	return object
+}
+
+// matchingItem returns the index in target of the counterpart of the source
+// item at index, or -1 when target has none
+func matchingItem(section string, source interface{}, target []interface{}, index int) int {
+	match := matchItems(sectionKeys[section], genericItems(source), genericItems(target))
+	for at, from := range match {
+		if from == index {
+			return at
+		}
+	}
+	return -1
+}
+
+func genericItems(value interface{}) []item {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return splitItems(data)
+}
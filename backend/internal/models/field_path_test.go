@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    FieldPath
+		wantErr bool
+	}{
+		{"basics.summary", FieldPath{Section: "basics", Index: -1, Field: "summary"}, false},
+		{"basics.location.city", FieldPath{Section: "basics", Index: -1, Field: "location.city"}, false},
+		{"skills", FieldPath{Section: "skills", Index: -1}, false},
+		{"projects[1]", FieldPath{Section: "projects", Index: 1}, false},
+		{"work[0].highlights", FieldPath{Section: "work", Index: 0, Field: "highlights"}, false},
+		{"hobbies", FieldPath{}, true},
+		{"basics[0]", FieldPath{}, true},
+		{"work.highlights", FieldPath{}, true},
+		{"work[-1]", FieldPath{}, true},
+		{"work[0", FieldPath{}, true},
+		{"work[0]highlights", FieldPath{}, true},
+		{"work[0].highlights[1]", FieldPath{}, true},
+		{"basics..summary", FieldPath{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ParseFieldPath(tt.path)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFieldPath) {
+					t.Fatalf("expected ErrInvalidFieldPath, got %+v, %v", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseFieldPath() = %+v, %v, want %+v", got, err, tt.want)
+			}
+			if got.String() != tt.path {
+				t.Errorf("String() = %q, want %q", got.String(), tt.path)
+			}
+		})
+	}
+}
+
+func TestRestoreFields(t *testing.T) {
+	source := &JSONResume{
+		Basics: &Basics{Name: "Ada Lovelace", Summary: "Wrote the first program.", Location: &Location{City: "London"}},
+		Work: []Work{
+			{Name: "Analytical Engines", Position: "Engineer", Highlights: []string{"Wrote notes on the engine"}},
+			{Name: "Royal Society", Position: "Fellow"},
+		},
+		Projects: []Project{{Name: "Note G", Description: "Bernoulli numbers"}},
+	}
+	target := &JSONResume{
+		Basics: &Basics{Name: "Ada Lovelace", Summary: "Pioneering programmer."},
+		Work: []Work{
+			{Name: "Royal Society", Position: "Fellow", Summary: "Tailored"},
+			{Name: "Analytical Engines", Position: "Engineer", Highlights: []string{"Led algorithm design"}},
+		},
+	}
+
+	var paths []FieldPath
+	for _, p := range []string{"basics.summary", "basics.location.city", "work[0].highlights", "projects[0]"} {
+		path, err := ParseFieldPath(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	got, err := RestoreFields(source, target, paths)
+	if err != nil {
+		t.Fatalf("RestoreFields: %v", err)
+	}
+
+	want := &JSONResume{
+		Basics: &Basics{Name: "Ada Lovelace", Summary: "Wrote the first program.", Location: &Location{City: "London"}},
+		Work: []Work{
+			{Name: "Royal Society", Position: "Fellow", Summary: "Tailored"},
+			{Name: "Analytical Engines", Position: "Engineer", Highlights: []string{"Wrote notes on the engine"}},
+		},
+		Projects: []Project{{Name: "Note G", Description: "Bernoulli numbers"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RestoreFields() = %+v, want %+v", got, want)
+	}
+	if target.Basics.Summary != "Pioneering programmer." {
+		t.Error("expected the target to be left unchanged")
+	}
+
+	missing, _ := ParseFieldPath("awards[0]")
+	if _, err := RestoreFields(source, target, []FieldPath{missing}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"cv-gen/backend/internal/terms"
+)
+
+// Remote policies of a job
+const (
+	RemotePolicyOnsite = "onsite"
+	RemotePolicyHybrid = "hybrid"
+	RemotePolicyRemote = "remote"
+)
+
+// JobRequirements is a job description parsed into structured data
+type JobRequirements struct {
+	MustHave   []Requirement `json:"must_have"`
+	NiceToHave []Requirement `json:"nice_to_have"`
+	// YearsOfExperience is the minimum experience asked for; 0 when the job does not say
+	YearsOfExperience int    `json:"years_of_experience,omitempty"`
+	Seniority         string `json:"seniority,omitempty"`
+	EmploymentType    string `json:"employment_type,omitempty"`
+	Location          string `json:"location,omitempty"`
+	// RemotePolicy is one of the RemotePolicy constants, or empty when the job does not say
+	RemotePolicy string       `json:"remote_policy,omitempty"`
+	Salary       *SalaryRange `json:"salary,omitempty"`
+	TechStack    []string     `json:"tech_stack"`
+}
+
+// SalaryRange is the pay a job offers; a bound the job does not state is 0
+type SalaryRange struct {
+	Min      int    `json:"min,omitempty"`
+	Max      int    `json:"max,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	// Period is what the amounts are paid per, such as "year" or "hour"
+	Period string `json:"period,omitempty"`
+}
+
+// Requirement is a single requirement of a job
+type Requirement struct {
+	Text string `json:"text"`
+	// Keywords are the skills, tools or qualifications the requirement names
+	Keywords []string `json:"keywords"`
+	// Covered is set by CoverRequirements when the profile mentions every keyword
+	Covered bool `json:"covered"`
+	// Evidence lists the profile fields that mention the keywords, as field paths
+	Evidence []string `json:"evidence,omitempty"`
+}
+
+// CoverRequirements marks each requirement as covered when every one of its
+// keywords appears in the profile, and records where they appear. Keywords
+// match whole words the way the keyword score matches them, so "Golang"
+// matches "Go" but "Google" does not. Requirements without keywords, such as
+// soft skills, are never covered.
+func CoverRequirements(profile *JSONResume, requirements *JobRequirements) {
+	if requirements == nil {
+		return
+	}
+	texts := profileTexts(profile)
+
+	cover := func(list []Requirement) {
+		for i := range list {
+			r := &list[i]
+			r.Covered, r.Evidence = len(r.Keywords) > 0, nil
+			for _, keyword := range r.Keywords {
+				found := false
+				for _, text := range texts {
+					if !text.value.Mentions(keyword) {
+						continue
+					}
+					found = true
+					if indexOf(r.Evidence, text.path) < 0 {
+						r.Evidence = append(r.Evidence, text.path)
+					}
+				}
+				if !found {
+					r.Covered = false
+				}
+			}
+		}
+	}
+	cover(requirements.MustHave)
+	cover(requirements.NiceToHave)
+}
+
+// profileText is a piece of text in a profile with the field path it is at
+type profileText struct {
+	path  string
+	value *terms.Text
+}
+
+// unmatchedFields hold contact details and dates rather than qualifications
+var unmatchedFields = map[string]bool{
+	"url": true, "email": true, "phone": true, "image": true,
+	"startDate": true, "endDate": true, "date": true, "releaseDate": true,
+}
+
+// profileTexts returns the text of every field in a profile, in section order.
+// Strings in lists inside an item, such as highlights, share the path of their list.
+func profileTexts(profile *JSONResume) []profileText {
+	object := resumeObject(profile)
+
+	var texts []profileText
+	var walk func(path string, value interface{})
+	walk = func(path string, value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if strings.TrimSpace(v) != "" {
+				texts = append(texts, profileText{path: path, value: terms.NewText(v)})
+			}
+		case []interface{}:
+			for _, element := range v {
+				walk(path, element)
+			}
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				if !unmatchedFields[key] {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(path+"."+key, v[key])
+			}
+		}
+	}
+
+	for _, section := range ValidSections() {
+		switch value := object[section].(type) {
+		case []interface{}:
+			for i, element := range value {
+				walk(section+"["+strconv.Itoa(i)+"]", element)
+			}
+		default:
+			walk(section, value)
+		}
+	}
+	return texts
+}
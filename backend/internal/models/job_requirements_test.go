@@ -0,0 +1,62 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverRequirements(t *testing.T) {
+	profile := &JSONResume{
+		Basics: &Basics{
+			Summary: "Backend engineer building Go services.",
+			Email:   "go@example.com",
+		},
+		Work: []Work{
+			{Name: "Acme", Highlights: []string{"Moved billing to PostgreSQL", "Ran Kubernetes clusters"}},
+		},
+		Skills: []Skill{
+			{Name: "Languages", Keywords: []string{"Go", "C++"}},
+		},
+	}
+
+	requirements := &JobRequirements{
+		MustHave: []Requirement{
+			{Text: "5+ years of Go", Keywords: []string{"go"}},
+			{Text: "Go and PostgreSQL", Keywords: []string{"Go", "PostgreSQL"}},
+			{Text: "Go and Rust", Keywords: []string{"Go", "Rust"}},
+			{Text: "Strong communication skills"},
+			{Text: "Golang and Postgres", Keywords: []string{"Golang", "Postgres"}},
+		},
+		NiceToHave: []Requirement{
+			{Text: "C++", Keywords: []string{"C++"}},
+			{Text: "Google Cloud", Keywords: []string{"Goo"}},
+		},
+	}
+
+	CoverRequirements(profile, requirements)
+
+	tests := []struct {
+		got          Requirement
+		wantCovered  bool
+		wantEvidence []string
+	}{
+		{requirements.MustHave[0], true, []string{"basics.summary", "skills[0].keywords"}},
+		{requirements.MustHave[1], true, []string{"basics.summary", "skills[0].keywords", "work[0].highlights"}},
+		{requirements.MustHave[2], false, []string{"basics.summary", "skills[0].keywords"}},
+		{requirements.MustHave[3], false, nil},
+		{requirements.MustHave[4], true, []string{"basics.summary", "skills[0].keywords", "work[0].highlights"}},
+		{requirements.NiceToHave[0], true, []string{"skills[0].keywords"}},
+		{requirements.NiceToHave[1], false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.got.Text, func(t *testing.T) {
+			if tt.got.Covered != tt.wantCovered {
+				t.Errorf("Covered = %v, want %v", tt.got.Covered, tt.wantCovered)
+			}
+			if !reflect.DeepEqual(tt.got.Evidence, tt.wantEvidence) {
+				t.Errorf("Evidence = %v, want %v", tt.got.Evidence, tt.wantEvidence)
+			}
+		})
+	}
+}
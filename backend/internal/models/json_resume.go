@@ -1,6 +1,11 @@
 // Package models provides domain models for the application
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 // JSONResume represents the complete JSON Resume schema
 // See: https://jsonresume.org/schema/
 type JSONResume struct {
@@ -191,3 +196,43 @@ func EmptyJSONResume() *JSONResume {
 		Projects:     []Project{},
 	}
 }
+
+// emptyBasics is the encoding of a Basics with no fields set, which counts as
+// an empty section
+var emptyBasics, _ = json.Marshal(&Basics{})
+
+// SectionsJSON returns the JSON encoding of each non-empty section, keyed by
+// section name
+func (r *JSONResume) SectionsJSON() map[string]json.RawMessage {
+	sections := map[string]json.RawMessage{}
+	if r == nil {
+		return sections
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return sections
+	}
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return sections
+	}
+
+	if bytes.Equal(sections["basics"], emptyBasics) {
+		delete(sections, "basics")
+	}
+	return sections
+}
+
+// ChangedSections returns the names of the sections that differ between two
+// resumes, in ValidSections order. A nil resume has no sections.
+func ChangedSections(before, after *JSONResume) []string {
+	a, b := before.SectionsJSON(), after.SectionsJSON()
+
+	changed := []string{}
+	for _, section := range ValidSections() {
+		if !bytes.Equal(a[section], b[section]) {
+			changed = append(changed, section)
+		}
+	}
+	return changed
+}
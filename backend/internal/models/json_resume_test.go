@@ -0,0 +1,41 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedSections(t *testing.T) {
+	base := &JSONResume{
+		Basics: &Basics{Name: "Ada Lovelace", Email: "ada@example.com"},
+		Work:   []Work{{Name: "Analytical Engines", Position: "Engineer"}},
+		Skills: []Skill{{Name: "Mathematics"}},
+	}
+
+	edited := &JSONResume{
+		Basics: &Basics{Name: "Ada Lovelace", Email: "ada@example.com"},
+		Work:   []Work{{Name: "Analytical Engines", Position: "Lead Engineer"}},
+		Skills: []Skill{{Name: "Mathematics"}},
+		Awards: []Award{{Title: "First Programmer"}},
+	}
+
+	tests := []struct {
+		name   string
+		before *JSONResume
+		after  *JSONResume
+		want   []string
+	}{
+		{"identical", base, base, []string{}},
+		{"edited and added", base, edited, []string{"work", "awards"}},
+		{"from nothing", nil, base, []string{"basics", "work", "skills"}},
+		{"empty resume counts as nothing", nil, EmptyJSONResume(), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChangedSections(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangedSections() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+// Finding kinds reported when verifying a tailored CV against the master profile
+const (
+	FindingNewEmployer    = "new_employer"
+	FindingChangedDates   = "changed_dates"
+	FindingNewDegree      = "new_degree"
+	FindingChangedDegree  = "changed_degree"
+	FindingNewCertificate = "new_certificate"
+	FindingNewSkill       = "new_skill"
+)
+
+// Actions taken on a finding
+const (
+	FindingFlagged  = "flagged"
+	FindingRemoved  = "removed"
+	FindingRestored = "restored"
+)
+
+// Verification is the result of checking a tailored CV for facts that are
+// not in the master profile
+type Verification struct {
+	Mode     string    `json:"mode"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a fact in a tailored CV that the master profile does not back.
+// Path locates it in the stored CV, except for a removed entry, which is
+// located where it was in the CV as generated.
+type Finding struct {
+	Kind    string `json:"kind"`
+	Path    string `json:"path"`
+	Value   string `json:"value"`
+	Source  string `json:"source,omitempty"`
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
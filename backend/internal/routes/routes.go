@@ -9,9 +9,21 @@ import (
 )
 
 // Register registers all routes with the Echo instance
-func Register(e *echo.Echo, h *handlers.Handler, aiHandler *handlers.AIHandler, coverLetterHandler *handlers.CoverLetterHandler) {
+func Register(e *echo.Echo, h *handlers.Handler, aiHandler *handlers.AIHandler, coverLetterHandler *handlers.CoverLetterHandler, paymentsHandler *handlers.PaymentsHandler, adminHandler *handlers.AdminHandler, shareHandler *handlers.ShareHandler, jobHandler *handlers.JobHandler, jobPageHandler *handlers.JobPageHandler, applicationHandler *handlers.ApplicationHandler) {
 	// Public routes (no auth required)
 	e.GET("/api/health", h.Health)
+	e.GET("/api/themes", h.ListThemes)
+
+	// Payment provider webhooks are authenticated by their signature
+	if paymentsHandler != nil {
+		e.POST("/api/webhooks/payments", paymentsHandler.PaymentWebhook)
+	}
+
+	// Shared CV links are authenticated by their token (and optional password)
+	if shareHandler != nil {
+		e.GET("/s/:token", shareHandler.ViewShare)
+		e.POST("/s/:token", shareHandler.ViewShare)
+	}
 
 	// Protected routes (auth required)
 	protected := e.Group("/api")
@@ -23,9 +35,14 @@ func Register(e *echo.Echo, h *handlers.Handler, aiHandler *handlers.AIHandler,
 	protected.PUT("/profile", h.UpdateProfile)
 	protected.PATCH("/profile/:section", h.UpdateProfileSection)
 	protected.DELETE("/profile", h.DeleteProfile)
+	protected.GET("/profile/versions", h.ListProfileVersions)
+	protected.GET("/profile/versions/diff", h.DiffProfileVersions)
+	protected.GET("/profile/versions/:version", h.GetProfileVersion)
+	protected.POST("/profile/versions/:version/restore", h.RestoreProfileVersion)
 
 	// Credits endpoints
 	protected.GET("/credits", h.Credits)
+	protected.GET("/credits/history", h.CreditsHistory)
 
 	// CV endpoints
 	protected.GET("/cvs", h.ListCVs)
@@ -34,6 +51,24 @@ func Register(e *echo.Echo, h *handlers.Handler, aiHandler *handlers.AIHandler,
 	protected.PUT("/cvs/:id", h.UpdateCV)
 	protected.DELETE("/cvs/:id", h.DeleteCV)
 	protected.POST("/cvs/:id/duplicate", h.DuplicateCV)
+	protected.GET("/cvs/:id/diff", h.DiffCV)
+	protected.GET("/cvs/:id/keyword-match", h.KeywordMatch)
+	protected.GET("/cvs/:id/export.pdf", h.ExportCVPDF)
+	protected.GET("/cvs/:id/export.docx", h.ExportCVDOCX)
+	protected.GET("/cvs/:id/export.md", h.ExportCVMarkdown)
+	protected.GET("/cvs/:id/export.txt", h.ExportCVText)
+	protected.GET("/cvs/:id/export.tex", h.ExportCVLaTeX)
+	protected.GET("/cvs/:id/render.html", h.RenderCVHTML)
+	protected.GET("/cvs/:id/revisions", h.ListCVRevisions)
+	protected.GET("/cvs/:id/revisions/:rev", h.GetCVRevision)
+	protected.POST("/cvs/:id/revisions/:rev/restore", h.RestoreCVRevision)
+
+	// CV share link endpoints
+	if shareHandler != nil {
+		protected.GET("/cvs/:id/shares", shareHandler.ListShares)
+		protected.POST("/cvs/:id/shares", shareHandler.CreateShare)
+		protected.DELETE("/cvs/:id/shares/:share_id", shareHandler.RevokeShare)
+	}
 
 	// Cover letter endpoints
 	if coverLetterHandler != nil {
@@ -42,12 +77,49 @@ func Register(e *echo.Echo, h *handlers.Handler, aiHandler *handlers.AIHandler,
 		protected.GET("/cover-letters/:id", coverLetterHandler.GetCoverLetter)
 		protected.PUT("/cover-letters/:id", coverLetterHandler.UpdateCoverLetter)
 		protected.DELETE("/cover-letters/:id", coverLetterHandler.DeleteCoverLetter)
+		protected.GET("/cover-letters/:id/export.docx", coverLetterHandler.ExportCoverLetterDOCX)
+		protected.GET("/cover-letters/:id/export.md", coverLetterHandler.ExportCoverLetterMarkdown)
+	}
+
+	// Saved job endpoints
+	if jobHandler != nil {
+		protected.GET("/jobs", jobHandler.ListJobs)
+		protected.POST("/jobs", jobHandler.CreateJob)
+		protected.GET("/jobs/:id", jobHandler.GetJob)
+		protected.PUT("/jobs/:id", jobHandler.UpdateJob)
+		protected.DELETE("/jobs/:id", jobHandler.DeleteJob)
+	}
+
+	// Job import endpoint - reads a posting from its page, no AI provider needed
+	if jobPageHandler != nil {
+		protected.POST("/ai/parse-job-url", jobPageHandler.ParseJobURL)
+	}
+
+	// Application tracking endpoints
+	if applicationHandler != nil {
+		protected.GET("/applications", applicationHandler.ListApplications)
+		protected.POST("/applications", applicationHandler.CreateApplication)
+		protected.GET("/applications/:id", applicationHandler.GetApplication)
+		protected.PUT("/applications/:id", applicationHandler.UpdateApplication)
+		protected.DELETE("/applications/:id", applicationHandler.DeleteApplication)
+		protected.POST("/applications/:id/status", applicationHandler.ChangeStatus)
 	}
 
 	// AI endpoints
 	if aiHandler != nil {
 		protected.POST("/ai/analyze-job", aiHandler.AnalyzeJob)
 		protected.POST("/ai/generate-cv", aiHandler.GenerateCV)
+		protected.POST("/ai/generate-cv/stream", aiHandler.GenerateCVStream)
 		protected.POST("/ai/generate-cover-letter", aiHandler.GenerateCoverLetter)
+		protected.POST("/ai/generate-cover-letter/stream", aiHandler.GenerateCoverLetterStream)
+	}
+
+	// Admin endpoints - restricted to admins on top of authentication
+	if adminHandler != nil {
+		admin := protected.Group("/admin", appMiddleware.RequireAdmin(adminHandler.AdminUserIDs()))
+		admin.GET("/top-users", adminHandler.TopUsers)
+		admin.GET("/users/:user_id/credits", adminHandler.GetUserCredits)
+		admin.POST("/users/:user_id/credits", adminHandler.GrantCredits)
+		admin.POST("/users/:user_id/credits/reset-free", adminHandler.ResetFreeUsage)
 	}
 }
@@ -0,0 +1,117 @@
+// Package admin provides support operations on user credits
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/services/credits"
+)
+
+var (
+	// ErrNotFound is returned when the user has no credits record
+	ErrNotFound = errors.New("user not found")
+	// ErrInvalidAmount is returned when a grant of zero credits is requested
+	ErrInvalidAmount = errors.New("amount must be non-zero")
+	// ErrInsufficientPaidCredits is returned when revoking more paid credits than the user has
+	ErrInsufficientPaidCredits = credits.ErrInsufficientPaidCredits
+)
+
+// Service provides admin operations on user credits
+type Service struct {
+	queries *db.Queries
+	credits *credits.Service
+}
+
+// New creates a new admin service
+func New(queries *db.Queries, creditsService *credits.Service) *Service {
+	return &Service{
+		queries: queries,
+		credits: creditsService,
+	}
+}
+
+// UserCreditsResponse is a user's credit balance as seen by an admin
+type UserCreditsResponse struct {
+	UserID               string `json:"user_id"`
+	FreeGenerationsUsed  int32  `json:"free_generations_used"`
+	FreeGenerationsLimit int32  `json:"free_generations_limit"`
+	PaidCredits          int32  `json:"paid_credits"`
+	TotalGenerations     int32  `json:"total_generations"`
+	Remaining            int32  `json:"remaining"`
+}
+
+// GetUserCredits returns a user's credit balance
+func (s *Service) GetUserCredits(ctx context.Context, userID string) (*UserCreditsResponse, error) {
+	userCredits, err := s.queries.GetUserCredits(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get credits: %w", err)
+	}
+
+	return toUserCreditsResponse(userCredits), nil
+}
+
+// GrantCredits adds paid credits to a user, or revokes them when amount is negative
+func (s *Service) GrantCredits(ctx context.Context, userID string, amount int32) (*UserCreditsResponse, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	reason := credits.ReasonAdminGrant
+	if amount < 0 {
+		reason = credits.ReasonAdminRevoke
+	}
+
+	userCredits, err := s.credits.Grant(ctx, userID, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserCreditsResponse(userCredits), nil
+}
+
+// ResetFreeUsage gives a user their full free allowance again
+func (s *Service) ResetFreeUsage(ctx context.Context, userID string) (*UserCreditsResponse, error) {
+	userCredits, err := s.credits.ResetFreeUsage(ctx, userID, credits.ReasonAdminReset)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserCreditsResponse(userCredits), nil
+}
+
+// TopUsers returns the users with the most generations
+func (s *Service) TopUsers(ctx context.Context, limit int) ([]UserCreditsResponse, error) {
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	rows, err := s.queries.ListTopUsersByGenerations(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top users: %w", err)
+	}
+
+	users := make([]UserCreditsResponse, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, *toUserCreditsResponse(row))
+	}
+	return users, nil
+}
+
+func toUserCreditsResponse(userCredits db.UserCredit) *UserCreditsResponse {
+	return &UserCreditsResponse{
+		UserID:               userCredits.UserID,
+		FreeGenerationsUsed:  userCredits.FreeGenerationsUsed,
+		FreeGenerationsLimit: userCredits.FreeGenerationsLimit,
+		PaidCredits:          userCredits.PaidCredits,
+		TotalGenerations:     userCredits.TotalGenerations,
+		Remaining:            credits.Remaining(userCredits),
+	}
+}
@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotScripted is returned by FakeProvider when no response is scripted for a prompt kind
+var ErrNotScripted = errors.New("no fake response scripted")
+
+// FakeResponse is a canned response returned by FakeProvider
+type FakeResponse struct {
+	Text string
+	Err  error
+}
+
+// FakeCall records a single call made to FakeProvider
+type FakeCall struct {
+	Kind   promptKind
+	Prompt string
+	Schema map[string]interface{}
+}
+
+// FakeProvider is a deterministic LLMProvider for tests that replays scripted
+// responses keyed by the prompt kind the call's context is tagged with. Responses for a kind are returned in order; the last
+// one is repeated once the script is exhausted. It is safe for concurrent use.
+type FakeProvider struct {
+	mu        sync.Mutex
+	responses map[promptKind][]FakeResponse
+	calls     []FakeCall
+}
+
+// Compile-time check that FakeProvider implements LLMProvider
+var _ LLMProvider = (*FakeProvider)(nil)
+
+// NewFakeProvider creates a fake provider with no scripted responses
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		responses: make(map[promptKind][]FakeResponse),
+	}
+}
+
+// Script queues responses for the given prompt kind and returns the provider for chaining
+func (f *FakeProvider) Script(kind promptKind, responses ...FakeResponse) *FakeProvider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[kind] = append(f.responses[kind], responses...)
+	return f
+}
+
+// Calls returns a copy of the calls made so far
+func (f *FakeProvider) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]FakeCall, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// GenerateJSON returns the next scripted response for the prompt's kind
+func (f *FakeProvider) GenerateJSON(ctx context.Context, prompt string, schema map[string]interface{}) (string, error) {
+	return f.next(ctx, prompt, schema)
+}
+
+// GenerateText returns the next scripted response for the prompt's kind
+func (f *FakeProvider) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return f.next(ctx, prompt, nil)
+}
+
+// Close is a no-op for the fake provider
+func (f *FakeProvider) Close() error {
+	return nil
+}
+
+// next records the call and pops the next scripted response
+func (f *FakeProvider) next(ctx context.Context, prompt string, schema map[string]interface{}) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrGenerationFailed, err)
+	}
+
+	kind := promptKindFrom(ctx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, FakeCall{Kind: kind, Prompt: prompt, Schema: schema})
+
+	queue := f.responses[kind]
+	if len(queue) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNotScripted, kind)
+	}
+
+	response := queue[0]
+	if len(queue) > 1 {
+		f.responses[kind] = queue[1:]
+	}
+
+	return response.Text, response.Err
+}
@@ -2,9 +2,9 @@ package ai
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"google.golang.org/genai"
 )
@@ -18,17 +18,20 @@ var (
 	ErrInvalidResponse = errors.New("invalid AI response")
 )
 
-// GeminiClient wraps the Google Gemini API client
+// GeminiClient wraps the Google Gemini API client and implements LLMProvider
 type GeminiClient struct {
 	client *genai.Client
 	model  string
 }
 
-// NewGeminiClient creates a new Gemini client
-func NewGeminiClient(apiKey string) (*GeminiClient, error) {
+// NewGeminiClient creates a new Gemini client for the given model
+func NewGeminiClient(apiKey string, model string) (*GeminiClient, error) {
 	if apiKey == "" {
 		return nil, ErrAPIKeyNotSet
 	}
+	if model == "" {
+		return nil, ErrModelNotSet
+	}
 
 	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
 		APIKey: apiKey,
@@ -39,7 +42,7 @@ func NewGeminiClient(apiKey string) (*GeminiClient, error) {
 
 	return &GeminiClient{
 		client: client,
-		model:  "gemini-2.5-flash",
+		model:  model,
 	}, nil
 }
 
@@ -54,7 +57,7 @@ func (g *GeminiClient) GenerateJSON(ctx context.Context, prompt string, schema m
 		},
 	)
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrGenerationFailed, err)
+		return "", geminiError(err)
 	}
 
 	return response.Text(), nil
@@ -68,56 +71,53 @@ func (g *GeminiClient) GenerateText(ctx context.Context, prompt string) (string,
 		nil,
 	)
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrGenerationFailed, err)
+		return "", geminiError(err)
 	}
 
 	return response.Text(), nil
 }
 
-// AnalyzeJob analyzes a job description against a candidate profile
-func (g *GeminiClient) AnalyzeJob(ctx context.Context, profileJSON string, jobDescription string) (*JobAnalysis, error) {
-	prompt := buildJobAnalysisPrompt(profileJSON, jobDescription)
-
-	responseText, err := g.GenerateJSON(ctx, prompt, jobAnalysisSchema)
-	if err != nil {
-		return nil, err
-	}
-
-	var analysis JobAnalysis
-	if err := json.Unmarshal([]byte(responseText), &analysis); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
-	}
-
-	return &analysis, nil
+// GenerateJSONStream streams content with a JSON schema constraint
+func (g *GeminiClient) GenerateJSONStream(ctx context.Context, prompt string, schema map[string]interface{}, onChunk ChunkFunc) (string, error) {
+	return g.stream(ctx, prompt, &genai.GenerateContentConfig{
+		ResponseMIMEType:   "application/json",
+		ResponseJsonSchema: schema,
+	}, onChunk)
 }
 
-// TailorCV generates a tailored CV based on the profile and job description
-func (g *GeminiClient) TailorCV(ctx context.Context, profileJSON string, jobDescription string, analysis *JobAnalysis) (string, error) {
-	analysisJSON, err := json.Marshal(analysis)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal analysis: %w", err)
-	}
-
-	prompt := buildCVTailoringPrompt(profileJSON, jobDescription, string(analysisJSON))
+// GenerateTextStream streams plain text content
+func (g *GeminiClient) GenerateTextStream(ctx context.Context, prompt string, onChunk ChunkFunc) (string, error) {
+	return g.stream(ctx, prompt, nil, onChunk)
+}
 
-	responseText, err := g.GenerateJSON(ctx, prompt, jsonResumeSchema)
-	if err != nil {
-		return "", err
+// stream runs a streaming generation, forwarding each chunk and returning the full text
+func (g *GeminiClient) stream(ctx context.Context, prompt string, config *genai.GenerateContentConfig, onChunk ChunkFunc) (string, error) {
+	var full strings.Builder
+	for response, err := range g.client.Models.GenerateContentStream(ctx, g.model, genai.Text(prompt), config) {
+		if err != nil {
+			return "", geminiError(err)
+		}
+
+		chunk := response.Text()
+		if chunk == "" {
+			continue
+		}
+		full.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return "", err
+		}
 	}
 
-	return responseText, nil
+	return full.String(), nil
 }
 
-// GenerateCoverLetter generates a cover letter based on the profile and job details
-func (g *GeminiClient) GenerateCoverLetter(ctx context.Context, profileJSON string, jobTitle string, companyName string, jobDescription string, cvSummary string) (string, error) {
-	prompt := buildCoverLetterPrompt(profileJSON, jobTitle, companyName, jobDescription, cvSummary)
-
-	responseText, err := g.GenerateText(ctx, prompt)
-	if err != nil {
-		return "", err
+// geminiError wraps a Gemini API error, exposing its HTTP status for retries
+func geminiError(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) && apiErr.Code != 0 {
+		return fmt.Errorf("%w: %w", ErrGenerationFailed, &APIStatusError{StatusCode: apiErr.Code, Message: apiErr.Message})
 	}
-
-	return responseText, nil
+	return fmt.Errorf("%w: %w", ErrGenerationFailed, err)
 }
 
 // Close closes the Gemini client (no-op for this client)
@@ -0,0 +1,116 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+)
+
+// ErrJobNotFound is returned when a request references a saved job the user does not have
+var ErrJobNotFound = errors.New("job not found")
+
+// getJob loads one of the user's saved jobs; a request without a job ID has no job
+func (s *Service) getJob(ctx context.Context, userID, jobID string) (*db.Job, error) {
+	if jobID == "" {
+		return nil, nil
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(jobID); err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	job, err := s.queries.GetJobByUserAndId(ctx, db.GetJobByUserAndIdParams{ID: id, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return &job, nil
+}
+
+// analyzeFor analyzes a job description against the profile. When the
+// description is the one of a saved job, the analysis is cached per master
+// profile version and description, and reused until either changes.
+func (s *Service) analyzeFor(ctx context.Context, job *db.Job, profile *profileSnapshot, jobDescription string) (*JobAnalysis, error) {
+	// Profiles saved before version history existed cannot be cached against
+	if job == nil || job.Description != jobDescription || !profile.version.Valid {
+		return s.analyzeJob(ctx, profile.json, jobDescription)
+	}
+
+	// An analysis that finishes after the description changed is cached under
+	// the old description, so it is never served for the new one
+	key := db.GetJobAnalysisParams{
+		JobID:           job.ID,
+		ProfileVersion:  profile.version.Int32,
+		DescriptionHash: descriptionHash(jobDescription),
+	}
+	cached, err := s.queries.GetJobAnalysis(ctx, key)
+	if err == nil {
+		var analysis JobAnalysis
+		if err := json.Unmarshal(cached.Analysis, &analysis); err == nil {
+			return &analysis, nil
+		}
+		log.Printf("WARNING: ignoring unreadable cached analysis of job %s: %v", uuidToString(job.ID), err)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get cached analysis: %w", err)
+	}
+
+	analysis, err := s.analyzeJob(ctx, profile.json, jobDescription)
+	if err != nil {
+		return nil, err
+	}
+
+	// The analysis is paid for at this point, so a failed cache write only costs the next call
+	data, err := json.Marshal(analysis)
+	if err == nil {
+		_, err = s.queries.UpsertJobAnalysis(ctx, db.UpsertJobAnalysisParams{
+			JobID:           key.JobID,
+			ProfileVersion:  key.ProfileVersion,
+			DescriptionHash: key.DescriptionHash,
+			Analysis:        data,
+		})
+	}
+	if err != nil {
+		log.Printf("ERROR: failed to cache analysis of job %s: %v", uuidToString(job.ID), err)
+	}
+
+	return analysis, nil
+}
+
+// descriptionHash identifies a job description in the analysis cache
+func descriptionHash(description string) string {
+	sum := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(sum[:])
+}
+
+// fillFromJob sets each empty field to the matching value of a saved job
+func fillFromJob(job *db.Job, title, company, url, description *string) {
+	fill := func(field *string, value pgtype.Text) {
+		if field != nil && *field == "" && value.Valid {
+			*field = value.String
+		}
+	}
+	fill(title, job.Title)
+	fill(company, job.CompanyName)
+	fill(url, job.JobUrl)
+	fill(description, pgtype.Text{String: job.Description, Valid: true})
+}
+
+// jobID returns the ID of a saved job, or an invalid UUID without one
+func jobID(job *db.Job) pgtype.UUID {
+	if job == nil {
+		return pgtype.UUID{}
+	}
+	return job.ID
+}
@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIClient talks to any server implementing the OpenAI /v1/chat/completions
+// protocol (OpenAI, vLLM, llama.cpp server, ...) and implements LLMProvider
+type OpenAIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+// Compile-time check that OpenAIClient implements LLMProvider
+var _ LLMProvider = (*OpenAIClient)(nil)
+
+// NewOpenAIClient creates a new OpenAI-compatible client.
+// baseURL should include the API version prefix (e.g. http://localhost:8000/v1).
+// If httpClient is nil a client with a sensible timeout is used.
+func NewOpenAIClient(baseURL string, apiKey string, model string, httpClient *http.Client) (*OpenAIClient, error) {
+	if model == "" {
+		return nil, ErrModelNotSet
+	}
+	if baseURL == "" {
+		return nil, ErrBaseURLNotSet
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 120 * time.Second}
+	}
+
+	return &OpenAIClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+	}, nil
+}
+
+// chatMessage is a single message in a chat completion request or response
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatResponseFormat constrains the model output format
+type chatResponseFormat struct {
+	Type       string          `json:"type"`
+	JSONSchema *chatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// chatJSONSchema describes a named JSON schema for structured output
+type chatJSONSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// chatCompletionRequest is the body of POST /chat/completions
+type chatCompletionRequest struct {
+	Model          string              `json:"model"`
+	Messages       []chatMessage       `json:"messages"`
+	ResponseFormat *chatResponseFormat `json:"response_format,omitempty"`
+}
+
+// chatCompletionResponse is the subset of the chat completion response we use
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// GenerateJSON generates content with a JSON schema constraint
+func (o *OpenAIClient) GenerateJSON(ctx context.Context, prompt string, schema map[string]interface{}) (string, error) {
+	return o.complete(ctx, chatCompletionRequest{
+		Model:    o.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+		ResponseFormat: &chatResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &chatJSONSchema{
+				Name:   "response",
+				Schema: schema,
+			},
+		},
+	})
+}
+
+// GenerateText generates plain text content
+func (o *OpenAIClient) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return o.complete(ctx, chatCompletionRequest{
+		Model:    o.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	})
+}
+
+// complete sends a chat completion request and returns the first choice's content
+func (o *OpenAIClient) complete(ctx context.Context, body chatCompletionRequest) (string, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal chat request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGenerationFailed, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to read response: %v", ErrGenerationFailed, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %w", ErrGenerationFailed, &APIStatusError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))})
+	}
+
+	var completion chatCompletionResponse
+	if err := json.Unmarshal(respBody, &completion); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("%w: no choices returned", ErrInvalidResponse)
+	}
+
+	return completion.Choices[0].Message.Content, nil
+}
+
+// Close closes the OpenAI client (no-op, the HTTP client is shared)
+func (o *OpenAIClient) Close() error {
+	return nil
+}
@@ -0,0 +1,87 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAIClientGenerateJSON(t *testing.T) {
+	var got chatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"match_score\":80}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL+"/v1/", "test-key", "local-model", server.Client())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient: %v", err)
+	}
+
+	text, err := client.GenerateJSON(context.Background(), "analyze", jobAnalysisSchema)
+	if err != nil {
+		t.Fatalf("GenerateJSON: %v", err)
+	}
+	if text != `{"match_score":80}` {
+		t.Errorf("unexpected content %q", text)
+	}
+
+	if got.Model != "local-model" {
+		t.Errorf("unexpected model %q", got.Model)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Content != "analyze" {
+		t.Errorf("unexpected messages %+v", got.Messages)
+	}
+	if got.ResponseFormat == nil || got.ResponseFormat.Type != "json_schema" || got.ResponseFormat.JSONSchema == nil {
+		t.Fatalf("expected json_schema response format, got %+v", got.ResponseFormat)
+	}
+	if _, ok := got.ResponseFormat.JSONSchema.Schema["properties"].(map[string]interface{})["match_score"]; !ok {
+		t.Errorf("schema was not forwarded: %+v", got.ResponseFormat.JSONSchema.Schema)
+	}
+}
+
+func TestOpenAIClientGenerateTextErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"server error", http.StatusServiceUnavailable, `{"error":"overloaded"}`, ErrGenerationFailed},
+		{"malformed body", http.StatusOK, `not json`, ErrInvalidResponse},
+		{"no choices", http.StatusOK, `{"choices":[]}`, ErrInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewOpenAIClient(server.URL, "", "local-model", server.Client())
+			if err != nil {
+				t.Fatalf("NewOpenAIClient: %v", err)
+			}
+
+			_, err = client.GenerateText(context.Background(), "write")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,38 @@
 package ai
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// promptKind identifies which prompt builder produced a prompt
+type promptKind string
+
+// Prompt kinds produced by the prompt builders in this file
+const (
+	promptKindJobAnalysis     promptKind = "job_analysis"
+	promptKindJobRequirements promptKind = "job_requirements"
+	promptKindCVTailoring     promptKind = "cv_tailoring"
+	promptKindCoverLetter     promptKind = "cover_letter"
+	promptKindUnknown         promptKind = "unknown"
+)
+
+// promptKindKey is the context key of the prompt kind
+type promptKindKey struct{}
+
+// withPromptKind tags the LLM calls made with ctx with the kind of prompt they send
+func withPromptKind(ctx context.Context, kind promptKind) context.Context {
+	return context.WithValue(ctx, promptKindKey{}, kind)
+}
+
+// promptKindFrom returns the prompt kind ctx is tagged with, or promptKindUnknown
+func promptKindFrom(ctx context.Context) promptKind {
+	if kind, ok := ctx.Value(promptKindKey{}).(promptKind); ok {
+		return kind
+	}
+	return promptKindUnknown
+}
 
 // jobAnalysisSchema defines the JSON schema for job analysis responses
 var jobAnalysisSchema = map[string]interface{}{
@@ -41,6 +73,76 @@ var jobAnalysisSchema = map[string]interface{}{
 	"required": []string{"match_score", "matching_skills", "missing_skills", "relevant_experiences", "suggestions", "keywords_to_include"},
 }
 
+// jobRequirementsSchema defines the JSON schema for job requirements responses
+var jobRequirementsSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"must_have": map[string]interface{}{
+			"type":        "array",
+			"items":       requirementSchema,
+			"description": "Requirements the job states as required",
+		},
+		"nice_to_have": map[string]interface{}{
+			"type":        "array",
+			"items":       requirementSchema,
+			"description": "Requirements the job states as preferred, a plus or a bonus",
+		},
+		"years_of_experience": map[string]interface{}{
+			"type":        "integer",
+			"minimum":     0,
+			"description": "Minimum years of experience asked for, 0 when not stated",
+		},
+		"seniority": map[string]interface{}{
+			"type":        "string",
+			"description": "One of intern, junior, mid, senior, lead, principal; empty when not stated",
+		},
+		"employment_type": map[string]interface{}{
+			"type":        "string",
+			"description": "One of full-time, part-time, contract, internship, temporary; empty when not stated",
+		},
+		"location": map[string]interface{}{
+			"type":        "string",
+			"description": "Where the job is based, empty when not stated",
+		},
+		"remote_policy": map[string]interface{}{
+			"type":        "string",
+			"description": "One of onsite, hybrid, remote; empty when not stated",
+		},
+		"salary": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"min":      map[string]interface{}{"type": "integer", "description": "Lowest amount, 0 when not stated"},
+				"max":      map[string]interface{}{"type": "integer", "description": "Highest amount, 0 when not stated"},
+				"currency": map[string]interface{}{"type": "string", "description": "ISO 4217 currency code"},
+				"period":   map[string]interface{}{"type": "string", "description": "One of year, month, day, hour"},
+			},
+		},
+		"tech_stack": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Languages, frameworks, databases and tools the job mentions",
+		},
+	},
+	"required": []string{"must_have", "nice_to_have", "years_of_experience", "seniority", "employment_type", "location", "remote_policy", "tech_stack"},
+}
+
+// requirementSchema defines a single requirement in jobRequirementsSchema
+var requirementSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"text": map[string]interface{}{
+			"type":        "string",
+			"description": "The requirement, phrased as in the job description",
+		},
+		"keywords": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Skills, tools or qualifications the requirement names, as short terms; empty for soft skills",
+		},
+	},
+	"required": []string{"text", "keywords"},
+}
+
 // jsonResumeSchema defines the JSON schema for tailored CV responses (JSON Resume format)
 var jsonResumeSchema = map[string]interface{}{
 	"type": "object",
@@ -251,8 +353,39 @@ Provide:
 Focus on being helpful and constructive. If the match isn't perfect, suggest how to best present their existing experience.`, profileJSON, jobDescription)
 }
 
-// buildCVTailoringPrompt creates the prompt for CV tailoring
-func buildCVTailoringPrompt(profileJSON string, jobDescription string, analysisJSON string) string {
+// buildJobRequirementsPrompt creates the prompt for parsing a job description into requirements
+func buildJobRequirementsPrompt(jobDescription string) string {
+	return fmt.Sprintf(`You are a recruiter extracting the requirements of a job posting into structured data.
+
+Job Description:
+%s
+
+Extract:
+- Must-have requirements: what the job states as required
+- Nice-to-have requirements: what the job states as preferred, a plus or a bonus
+- For each requirement, the skills, tools or qualifications it names as short keywords (for example "Go", "PostgreSQL", "Computer Science")
+- The minimum years of experience, seniority level and employment type
+- The location and whether the job is onsite, hybrid or remote
+- The salary range, if one is given
+- The tech stack: every language, framework, database and tool mentioned
+
+Only extract what the job description states. Leave a field empty, or 0 for numbers, when it is not stated; do not guess.`, jobDescription)
+}
+
+// buildCVTailoringPrompt creates the prompt for CV tailoring. requirementsJSON
+// is the output of CoverRequirements and rules come from buildTailoringRules;
+// either may be empty.
+func buildCVTailoringPrompt(profileJSON string, jobDescription string, analysisJSON string, requirementsJSON string, rules string) string {
+	var requirements string
+	if requirementsJSON != "" {
+		requirements = fmt.Sprintf(`
+
+Job Requirements ("covered" marks the requirements the profile shows evidence of, "evidence" lists where):
+%s
+
+Lead with the profile content that covers the must-have requirements. Do not claim uncovered requirements.`, requirementsJSON)
+	}
+
 	return fmt.Sprintf(`You are an expert resume writer. Create a tailored resume based on the candidate's master profile and the target job.
 
 Master Profile (JSON Resume format):
@@ -262,7 +395,7 @@ Target Job Description:
 %s
 
 Job Analysis:
-%s
+%s%s
 
 Create a tailored JSON Resume that:
 1. Rewrites the summary to directly address the job requirements and highlight the most relevant qualifications
@@ -277,9 +410,28 @@ CRITICAL RULES:
 - Only use information that exists in the original profile
 - You may rephrase and emphasize existing content, but never add fictional content
 - Quantify achievements where data exists in the original profile
-- Keep all dates, company names, and factual information accurate
+- Keep all dates, company names, and factual information accurate%s
+
+Return a valid JSON Resume. Do not include any markdown formatting or code blocks.`, profileJSON, jobDescription, analysisJSON, requirements, rules)
+}
 
-Return a valid JSON Resume. Do not include any markdown formatting or code blocks.`, profileJSON, jobDescription, analysisJSON)
+// buildTailoringRules creates the extra tailoring prompt rules for a section
+// selection and locked fields; it is empty when neither is set
+func buildTailoringRules(sections []string, locked []string) string {
+	var b strings.Builder
+	if len(sections) > 0 {
+		b.WriteString("\n\nSECTIONS:\n")
+		fmt.Fprintf(&b, "- Only include these sections: %s\n", strings.Join(sections, ", "))
+		b.WriteString("- Leave every other section out entirely")
+	}
+	if len(locked) > 0 {
+		b.WriteString("\n\nLOCKED FIELDS (paths into the master profile, list indexes start at 0):\n")
+		for _, path := range locked {
+			fmt.Fprintf(&b, "- %s\n", path)
+		}
+		b.WriteString("- Copy locked fields exactly as they are in the master profile; do not reword, shorten or drop them")
+	}
+	return b.String()
 }
 
 // buildCoverLetterPrompt creates the prompt for cover letter generation
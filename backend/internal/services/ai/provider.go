@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cv-gen/backend/internal/config"
+)
+
+// Supported LLM provider names for config.Config.AIProvider
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+)
+
+var (
+	// ErrUnknownProvider is returned when the configured LLM provider is not supported
+	ErrUnknownProvider = errors.New("unknown AI provider")
+	// ErrModelNotSet is returned when the configured LLM provider has no model
+	ErrModelNotSet = errors.New("AI model not set")
+	// ErrBaseURLNotSet is returned when the OpenAI-compatible provider has no base URL
+	ErrBaseURLNotSet = errors.New("AI base URL not set")
+)
+
+// LLMProvider is the interface implemented by every language model backend.
+// The job analysis, CV tailoring and cover letter flows only depend on this
+// interface, so any backend that can honour a JSON schema can be swapped in.
+type LLMProvider interface {
+	// GenerateJSON generates content constrained by the given JSON schema
+	GenerateJSON(ctx context.Context, prompt string, schema map[string]interface{}) (string, error)
+	// GenerateText generates plain text content
+	GenerateText(ctx context.Context, prompt string) (string, error)
+	// Close releases any resources held by the provider
+	Close() error
+}
+
+// ChunkFunc receives partial output while a provider streams a response.
+// Returning an error aborts the stream.
+type ChunkFunc func(chunk string) error
+
+// StreamingProvider is implemented by providers that can stream partial output.
+// Providers that don't implement it are streamed as a single final chunk.
+type StreamingProvider interface {
+	// GenerateJSONStream streams schema constrained content and returns the full text
+	GenerateJSONStream(ctx context.Context, prompt string, schema map[string]interface{}, onChunk ChunkFunc) (string, error)
+	// GenerateTextStream streams plain text content and returns the full text
+	GenerateTextStream(ctx context.Context, prompt string, onChunk ChunkFunc) (string, error)
+}
+
+// Compile-time checks that GeminiClient implements LLMProvider and StreamingProvider
+var (
+	_ LLMProvider       = (*GeminiClient)(nil)
+	_ StreamingProvider = (*GeminiClient)(nil)
+)
+
+// NewProvider creates the LLM provider selected by the configuration
+func NewProvider(cfg *config.Config) (LLMProvider, error) {
+	switch cfg.AIProvider {
+	case "", ProviderGemini:
+		return NewGeminiClient(cfg.GeminiAPIKey, cfg.GeminiModel)
+	case ProviderOpenAI:
+		return NewOpenAIClient(cfg.OpenAIBaseURL, cfg.OpenAIAPIKey, cfg.OpenAIModel, nil)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, cfg.AIProvider)
+	}
+}
+
+// generateJSON generates schema constrained content, streaming chunks to onChunk when it is set
+func generateJSON(ctx context.Context, llm LLMProvider, prompt string, schema map[string]interface{}, onChunk ChunkFunc) (string, error) {
+	if onChunk == nil {
+		return llm.GenerateJSON(ctx, prompt, schema)
+	}
+	if streamer, ok := llm.(StreamingProvider); ok {
+		return streamer.GenerateJSONStream(ctx, prompt, schema, onChunk)
+	}
+
+	text, err := llm.GenerateJSON(ctx, prompt, schema)
+	if err != nil {
+		return "", err
+	}
+	if err := onChunk(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// generateText generates plain text, streaming chunks to onChunk when it is set
+func generateText(ctx context.Context, llm LLMProvider, prompt string, onChunk ChunkFunc) (string, error) {
+	if onChunk == nil {
+		return llm.GenerateText(ctx, prompt)
+	}
+	if streamer, ok := llm.(StreamingProvider); ok {
+		return streamer.GenerateTextStream(ctx, prompt, onChunk)
+	}
+
+	text, err := llm.GenerateText(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	if err := onChunk(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
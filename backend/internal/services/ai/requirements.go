@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"cv-gen/backend/internal/models"
+)
+
+// parseRequirements parses a job description into structured requirements and
+// marks the ones the profile covers
+func (s *Service) parseRequirements(ctx context.Context, profile *models.JSONResume, jobDescription string) (*models.JobRequirements, error) {
+	prompt := buildJobRequirementsPrompt(jobDescription)
+
+	var requirements models.JobRequirements
+	err := s.generateDecoded(ctx, promptKindJobRequirements, prompt, jobRequirementsSchema, nil, func(responseText string) error {
+		requirements = models.JobRequirements{}
+		return json.Unmarshal([]byte(responseText), &requirements)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeRequirements(&requirements)
+	models.CoverRequirements(profile, &requirements)
+
+	return &requirements, nil
+}
+
+// normalizeRequirements cleans up parsed requirements: enumerated fields are
+// lower case, values the job did not state are empty and lists are never null
+func normalizeRequirements(r *models.JobRequirements) {
+	dropEmpty := func(list []models.Requirement) []models.Requirement {
+		kept := make([]models.Requirement, 0, len(list))
+		for _, requirement := range list {
+			requirement.Text = strings.TrimSpace(requirement.Text)
+			if requirement.Text == "" {
+				continue
+			}
+			if requirement.Keywords == nil {
+				requirement.Keywords = []string{}
+			}
+			kept = append(kept, requirement)
+		}
+		return kept
+	}
+	r.MustHave = dropEmpty(r.MustHave)
+	r.NiceToHave = dropEmpty(r.NiceToHave)
+
+	if r.YearsOfExperience < 0 {
+		r.YearsOfExperience = 0
+	}
+	r.Seniority = strings.ToLower(strings.TrimSpace(r.Seniority))
+	r.EmploymentType = strings.ToLower(strings.TrimSpace(r.EmploymentType))
+	r.Location = strings.TrimSpace(r.Location)
+
+	r.RemotePolicy = strings.ToLower(strings.TrimSpace(r.RemotePolicy))
+	switch r.RemotePolicy {
+	case models.RemotePolicyOnsite, models.RemotePolicyHybrid, models.RemotePolicyRemote:
+	default:
+		r.RemotePolicy = ""
+	}
+
+	if r.Salary != nil && r.Salary.Min <= 0 && r.Salary.Max <= 0 {
+		r.Salary = nil
+	}
+	if r.TechStack == nil {
+		r.TechStack = []string{}
+	}
+}
@@ -0,0 +1,264 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"cv-gen/backend/internal/config"
+)
+
+var (
+	// ErrRateLimited is returned when the provider keeps rejecting calls with 429
+	ErrRateLimited = errors.New("AI provider rate limit exceeded")
+	// ErrProviderUnavailable is returned when the provider keeps failing with transient errors
+	ErrProviderUnavailable = errors.New("AI provider unavailable")
+	// ErrTimeout is returned when a provider call exceeds its per-call timeout on every attempt
+	ErrTimeout = errors.New("AI provider call timed out")
+)
+
+// APIStatusError carries the HTTP status code returned by an LLM provider
+type APIStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIStatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// RetryPolicy controls timeouts and retries for LLM calls
+type RetryPolicy struct {
+	// CallTimeout bounds each individual attempt; zero means no per-call timeout
+	CallTimeout time.Duration
+	// MaxAttempts is the total number of attempts for retryable errors
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries; without it the delay never
+	// grows past InitialBackoff
+	MaxBackoff time.Duration
+	// InvalidJSONRetries is how many times a response that fails to decode is regenerated
+	InvalidJSONRetries int
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		CallTimeout:        60 * time.Second,
+		MaxAttempts:        3,
+		InitialBackoff:     500 * time.Millisecond,
+		MaxBackoff:         8 * time.Second,
+		InvalidJSONRetries: 1,
+	}
+}
+
+// RetryPolicyFromConfig builds a retry policy from the application configuration
+func RetryPolicyFromConfig(cfg *config.Config) RetryPolicy {
+	policy := RetryPolicy{
+		CallTimeout:        cfg.AICallTimeout,
+		MaxAttempts:        cfg.AIMaxAttempts,
+		InitialBackoff:     cfg.AIInitialBackoff,
+		MaxBackoff:         cfg.AIMaxBackoff,
+		InvalidJSONRetries: cfg.AIInvalidJSONRetries,
+	}
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.InvalidJSONRetries < 0 {
+		policy.InvalidJSONRetries = 0
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = max(policy.InitialBackoff, DefaultRetryPolicy().MaxBackoff)
+	}
+	return policy
+}
+
+// backoff returns the jittered delay before the given retry (1-based)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+	maxDelay := max(p.MaxBackoff, p.InitialBackoff)
+	// Doubling stops at the cap, so large retry numbers cannot overflow
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	// Full jitter: pick uniformly between half and the full delay
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// retryingProvider wraps an LLMProvider with per-call timeouts and retries
+type retryingProvider struct {
+	next   LLMProvider
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// WithRetry wraps a provider so every call honours the retry policy
+func WithRetry(next LLMProvider, policy RetryPolicy) LLMProvider {
+	return &retryingProvider{
+		next:   next,
+		policy: policy,
+		sleep:  sleepContext,
+	}
+}
+
+// Compile-time checks that retryingProvider implements LLMProvider and StreamingProvider
+var (
+	_ LLMProvider       = (*retryingProvider)(nil)
+	_ StreamingProvider = (*retryingProvider)(nil)
+)
+
+// GenerateJSON generates schema constrained content, retrying transient failures
+func (r *retryingProvider) GenerateJSON(ctx context.Context, prompt string, schema map[string]interface{}) (string, error) {
+	return r.do(ctx, func(callCtx context.Context) (string, error) {
+		return r.next.GenerateJSON(callCtx, prompt, schema)
+	}, nil)
+}
+
+// GenerateText generates plain text, retrying transient failures
+func (r *retryingProvider) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return r.do(ctx, func(callCtx context.Context) (string, error) {
+		return r.next.GenerateText(callCtx, prompt)
+	}, nil)
+}
+
+// GenerateJSONStream streams schema constrained content. Transient failures
+// are only retried while no chunk has been delivered to the caller.
+func (r *retryingProvider) GenerateJSONStream(ctx context.Context, prompt string, schema map[string]interface{}, onChunk ChunkFunc) (string, error) {
+	streamed := false
+	return r.do(ctx, func(callCtx context.Context) (string, error) {
+		return generateJSON(callCtx, r.next, prompt, schema, markStreamed(onChunk, &streamed))
+	}, &streamed)
+}
+
+// GenerateTextStream streams plain text. Transient failures are only retried
+// while no chunk has been delivered to the caller.
+func (r *retryingProvider) GenerateTextStream(ctx context.Context, prompt string, onChunk ChunkFunc) (string, error) {
+	streamed := false
+	return r.do(ctx, func(callCtx context.Context) (string, error) {
+		return generateText(callCtx, r.next, prompt, markStreamed(onChunk, &streamed))
+	}, &streamed)
+}
+
+// Close closes the wrapped provider
+func (r *retryingProvider) Close() error {
+	return r.next.Close()
+}
+
+// do runs call with a per-attempt timeout, retrying retryable errors with backoff.
+// If streamed becomes true the error is returned without retrying.
+func (r *retryingProvider) do(ctx context.Context, call func(ctx context.Context) (string, error), streamed *bool) (string, error) {
+	attempts := r.policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if err := r.sleep(ctx, r.policy.backoff(attempt-1)); err != nil {
+				return "", fmt.Errorf("%w: %w", ErrGenerationFailed, err)
+			}
+		}
+
+		callCtx, cancel := ctx, context.CancelFunc(func() {})
+		if r.policy.CallTimeout > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, r.policy.CallTimeout)
+		}
+		text, err := call(callCtx)
+		timedOut := errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+		cancel()
+
+		if err == nil {
+			return text, nil
+		}
+		if timedOut {
+			err = fmt.Errorf("%w: %w", ErrTimeout, err)
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || (streamed != nil && *streamed) || !isRetryable(err) {
+			break
+		}
+	}
+
+	return "", classifyError(lastErr)
+}
+
+// markStreamed wraps onChunk so the caller can tell whether output was delivered
+func markStreamed(onChunk ChunkFunc, streamed *bool) ChunkFunc {
+	return func(chunk string) error {
+		*streamed = true
+		return onChunk(chunk)
+	}
+}
+
+// isRetryable reports whether an error is worth another attempt
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+
+	var statusErr *APIStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// classifyError tags the final error with a typed sentinel handlers can map
+func classifyError(err error) error {
+	if errors.Is(err, ErrTimeout) {
+		return err
+	}
+
+	var statusErr *APIStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return fmt.Errorf("%w: %w", ErrRateLimited, err)
+		case statusErr.StatusCode >= http.StatusInternalServerError:
+			return fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+	}
+
+	return err
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// blockingProvider never answers before its context is done
+type blockingProvider struct {
+	FakeProvider
+}
+
+func (b *blockingProvider) GenerateText(ctx context.Context, prompt string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func statusResponse(code int) FakeResponse {
+	return FakeResponse{Err: &APIStatusError{StatusCode: code, Message: http.StatusText(code)}}
+}
+
+func TestWithRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	tests := []struct {
+		name      string
+		responses []FakeResponse
+		wantErr   error
+		wantCalls int
+	}{
+		{"succeeds first time", []FakeResponse{{Text: "ok"}}, nil, 1},
+		{"retries unavailable", []FakeResponse{statusResponse(503), statusResponse(502), {Text: "ok"}}, nil, 3},
+		{"gives up when rate limited", []FakeResponse{statusResponse(429)}, ErrRateLimited, 3},
+		{"gives up when unavailable", []FakeResponse{statusResponse(500)}, ErrProviderUnavailable, 3},
+		{"does not retry bad request", []FakeResponse{statusResponse(400)}, &APIStatusError{}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFakeProvider().Script(promptKindUnknown, tt.responses...)
+			llm := WithRetry(fake, policy)
+
+			text, err := llm.GenerateText(context.Background(), "hello")
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil || text != "ok" {
+					t.Fatalf("expected ok, got %q, %v", text, err)
+				}
+			case *APIStatusError:
+				if !errors.As(err, &want) || errors.Is(err, ErrProviderUnavailable) {
+					t.Fatalf("expected untyped status error, got %v", err)
+				}
+			default:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+			}
+			if calls := len(fake.Calls()); calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWithRetryCallTimeout(t *testing.T) {
+	llm := WithRetry(&blockingProvider{}, RetryPolicy{MaxAttempts: 2, CallTimeout: 10 * time.Millisecond})
+
+	_, err := llm.GenerateText(context.Background(), "hello")
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for retry, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: 300 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			if d := policy.backoff(retry); d < max/2 || d > max {
+				t.Fatalf("retry %d: backoff %v outside [%v, %v]", retry, d, max/2, max)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyBackoffLargeRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		max    time.Duration
+	}{
+		{"capped", RetryPolicy{InitialBackoff: 500 * time.Millisecond, MaxBackoff: 8 * time.Second}, 8 * time.Second},
+		{"no cap", RetryPolicy{InitialBackoff: 500 * time.Millisecond}, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, retry := range []int{40, 64, 1000} {
+				if d := tt.policy.backoff(retry); d < tt.max/2 || d > tt.max {
+					t.Errorf("retry %d: backoff %v outside [%v, %v]", retry, d, tt.max/2, tt.max)
+				}
+			}
+		})
+	}
+}
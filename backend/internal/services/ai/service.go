@@ -5,120 +5,233 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"cv-gen/backend/internal/ats"
+	"cv-gen/backend/internal/config"
 	"cv-gen/backend/internal/db"
 	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/services/credits"
+	cvSvc "cv-gen/backend/internal/services/cv"
+	"cv-gen/backend/internal/themes"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
 	// ErrOutOfCredits is returned when the user has no remaining credits
-	ErrOutOfCredits = errors.New("out of generation credits")
+	ErrOutOfCredits = credits.ErrOutOfCredits
 	// ErrProfileNotFound is returned when the user has no profile
 	ErrProfileNotFound = errors.New("profile not found")
 	// ErrEmptyJobDescription is returned when the job description is empty
 	ErrEmptyJobDescription = errors.New("job description cannot be empty")
+	// ErrMissingJobDetails is returned when a cover letter has no job title or company
+	ErrMissingJobDetails = errors.New("job_title and company_name are required")
 )
 
+// creditReleaseTimeout bounds refunding a credit after the request context is gone
+const creditReleaseTimeout = 5 * time.Second
+
+// CreditStore holds a generation credit while the LLM works and settles it afterwards
+// (implemented by credits.Service)
+type CreditStore interface {
+	Reserve(ctx context.Context, userID, reason string) (*credits.Reservation, error)
+	Commit(ctx context.Context, reservation *credits.Reservation) error
+	Release(ctx context.Context, reservation *credits.Reservation) error
+}
+
+// CVStore saves generated CVs together with their first revision
+// (implemented by cv.Service)
+type CVStore interface {
+	CreateWithRevision(ctx context.Context, params db.CreateCVParams, source string) (db.GeneratedCv, error)
+}
+
 // Service provides AI-powered CV generation and job analysis
 type Service struct {
-	gemini  *GeminiClient
-	queries *db.Queries
+	llm        LLMProvider
+	queries    db.Querier
+	credits    CreditStore
+	cvs        CVStore
+	retry      RetryPolicy
+	verifyMode string
 }
 
-// New creates a new AI service
-func New(geminiAPIKey string, queries *db.Queries) (*Service, error) {
-	gemini, err := NewGeminiClient(geminiAPIKey)
+// New creates a new AI service using the LLM provider selected in the configuration
+func New(cfg *config.Config, queries db.Querier, creditStore CreditStore, cvStore CVStore) (*Service, error) {
+	if cfg.AIVerifyMode != VerifyModeStrip && cfg.AIVerifyMode != VerifyModeFlag {
+		return nil, fmt.Errorf("unknown AI verify mode %q (want %q or %q)", cfg.AIVerifyMode, VerifyModeStrip, VerifyModeFlag)
+	}
+
+	llm, err := NewProvider(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Service{
-		gemini:  gemini,
-		queries: queries,
-	}, nil
+	policy := RetryPolicyFromConfig(cfg)
+	service := NewWithProvider(WithRetry(llm, policy), queries, creditStore, cvStore)
+	service.retry = policy
+	service.verifyMode = cfg.AIVerifyMode
+
+	return service, nil
 }
 
-// NewWithClient creates a new AI service with an existing Gemini client (for testing)
-func NewWithClient(gemini *GeminiClient, queries *db.Queries) *Service {
+// NewWithProvider creates a new AI service with an existing LLM provider (for testing)
+func NewWithProvider(llm LLMProvider, queries db.Querier, creditStore CreditStore, cvStore CVStore) *Service {
 	return &Service{
-		gemini:  gemini,
-		queries: queries,
+		llm:        llm,
+		queries:    queries,
+		credits:    creditStore,
+		cvs:        cvStore,
+		retry:      DefaultRetryPolicy(),
+		verifyMode: VerifyModeStrip,
 	}
 }
 
-// Close closes the AI service and its clients
+// Close closes the AI service and its provider
 func (s *Service) Close() error {
-	if s.gemini != nil {
-		return s.gemini.Close()
+	if s.llm != nil {
+		return s.llm.Close()
 	}
 	return nil
 }
 
-// AnalyzeJob analyzes a job description against the user's profile
-func (s *Service) AnalyzeJob(ctx context.Context, userID string, jobDescription string) (*JobAnalysis, error) {
+// AnalyzeJob analyzes a job description against the user's profile. Analyses
+// of saved jobs are cached, so analyzing one again is free until the job or
+// the profile changes.
+func (s *Service) AnalyzeJob(ctx context.Context, userID string, req *AnalyzeJobRequest) (*JobAnalysis, error) {
+	job, err := s.getJob(ctx, userID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	jobDescription := req.JobDescription
+	if job != nil {
+		fillFromJob(job, nil, nil, nil, &jobDescription)
+	}
 	if jobDescription == "" {
 		return nil, ErrEmptyJobDescription
 	}
 
 	// Get user's profile
-	profileJSON, err := s.getProfileJSON(ctx, userID)
+	profile, err := s.getProfile(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Analyze the job
-	return s.gemini.AnalyzeJob(ctx, profileJSON, jobDescription)
+	return s.analyzeFor(ctx, job, profile, jobDescription)
 }
 
 // GenerateCV generates a tailored CV based on a job description
 func (s *Service) GenerateCV(ctx context.Context, userID string, req *GenerateCVRequest) (*GenerateCVResponse, error) {
+	return s.generateCV(ctx, userID, req, nil)
+}
+
+// GenerateCVStream generates a tailored CV like GenerateCV, emitting progress
+// events and partial tailoring output as they arrive
+func (s *Service) GenerateCVStream(ctx context.Context, userID string, req *GenerateCVRequest, emit EmitFunc) (*GenerateCVResponse, error) {
+	return s.generateCV(ctx, userID, req, emit)
+}
+
+// generateCV implements GenerateCV and GenerateCVStream; emit may be nil
+func (s *Service) generateCV(ctx context.Context, userID string, req *GenerateCVRequest, emit EmitFunc) (_ *GenerateCVResponse, err error) {
+	job, err := s.getJob(ctx, userID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		filled := *req
+		fillFromJob(job, &filled.JobTitle, &filled.CompanyName, &filled.JobURL, &filled.JobDescription)
+		req = &filled
+	}
+
 	if req.JobDescription == "" {
 		return nil, ErrEmptyJobDescription
 	}
+	tailoring, err := newTailoring(req)
+	if err != nil {
+		return nil, err
+	}
 
-	// Check credits
-	credits, err := s.checkCredits(ctx, userID)
+	// Reserve a credit before calling the LLM; it is refunded if any later step fails
+	reservation, err := s.credits.Reserve(ctx, userID, credits.ReasonCVGeneration)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { s.settleCredit(ctx, reservation, err) }()
 
 	// Get user's profile
-	profileJSON, err := s.getProfileJSON(ctx, userID)
+	snapshot, err := s.getProfile(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	profile, profileJSON := snapshot.resume, snapshot.json
+	if err := tailoring.checkProfile(profile); err != nil {
+		return nil, err
+	}
 
 	// Analyze the job first
-	analysis, err := s.gemini.AnalyzeJob(ctx, profileJSON, req.JobDescription)
+	analysis, err := s.analyzeFor(ctx, job, snapshot, req.JobDescription)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze job: %w", err)
 	}
 
-	// Generate tailored CV
-	tailoredCVJSON, err := s.gemini.TailorCV(ctx, profileJSON, req.JobDescription, analysis)
+	if err := sendEvent(emit, EventAnalysisDone, analysis); err != nil {
+		return nil, err
+	}
+
+	// Requirements sharpen the tailoring but are not needed for it, so a failed parse is not fatal
+	requirements, err := s.parseRequirements(ctx, profile, req.JobDescription)
+	if err != nil {
+		log.Printf("WARNING: failed to parse job requirements for user %s: %v", userID, err)
+	} else if err := sendEvent(emit, EventRequirementsDone, requirements); err != nil {
+		return nil, err
+	}
+
+	if err := sendEvent(emit, EventTailoringStarted, nil); err != nil {
+		return nil, err
+	}
+
+	// Generate tailored CV from the selected sections, then put locked content back
+	tailoringJSON, err := tailoring.profileJSON(profile, profileJSON)
+	if err != nil {
+		return nil, err
+	}
+	tailoredResume, err := s.tailorCV(ctx, tailoringJSON, req.JobDescription, analysis, requirements, tailoring.promptRules(), emit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tailored CV: %w", err)
 	}
+	if tailoredResume, err = tailoring.apply(profile, tailoredResume); err != nil {
+		return nil, err
+	}
 
-	// Parse the tailored CV
-	var tailoredResume models.JSONResume
-	if err := json.Unmarshal([]byte(tailoredCVJSON), &tailoredResume); err != nil {
-		return nil, fmt.Errorf("%w: failed to parse tailored CV: %v", ErrInvalidResponse, err)
+	// Check the tailored CV for facts the profile does not back
+	verification := verifyTailoredCV(profile, tailoredResume, s.verifyMode)
+	if err := sendEvent(emit, EventVerificationDone, verification); err != nil {
+		return nil, err
 	}
 
+	// Score the keywords the tailored CV covers, which unlike the AI match score is reproducible
+	keywordMatch := ats.Score(req.JobDescription, tailoredResume)
+
 	// Save the CV to database
-	cvData, err := json.Marshal(&tailoredResume)
+	cvData, err := json.Marshal(tailoredResume)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal CV data: %w", err)
 	}
 
-	analysisData, err := json.Marshal(analysis)
+	analysisData, err := json.Marshal(storedSuggestions{JobAnalysis: analysis, Verification: verification})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal analysis: %w", err)
 	}
 
+	var requirementsData []byte
+	if requirements != nil {
+		if requirementsData, err = json.Marshal(requirements); err != nil {
+			return nil, fmt.Errorf("failed to marshal job requirements: %w", err)
+		}
+	}
+
 	cvName := req.CVName
 	if cvName == "" {
 		cvName = "Tailored CV"
@@ -127,79 +240,98 @@ func (s *Service) GenerateCV(ctx context.Context, userID string, req *GenerateCV
 		}
 	}
 
-	savedCV, err := s.queries.CreateCV(ctx, db.CreateCVParams{
-		UserID:         userID,
-		Name:           cvName,
-		JobUrl:         pgtype.Text{String: req.JobURL, Valid: req.JobURL != ""},
-		JobTitle:       pgtype.Text{String: req.JobTitle, Valid: req.JobTitle != ""},
-		CompanyName:    pgtype.Text{String: req.CompanyName, Valid: req.CompanyName != ""},
-		JobDescription: pgtype.Text{String: req.JobDescription, Valid: true},
-		CvData:         cvData,
-		MatchScore:     pgtype.Int4{Int32: int32(analysis.MatchScore), Valid: true},
-		AiSuggestions:  analysisData,
-		TemplateID:     pgtype.Text{String: "professional", Valid: true},
-	})
+	savedCV, err := s.cvs.CreateWithRevision(ctx, db.CreateCVParams{
+		UserID:          userID,
+		Name:            cvName,
+		JobUrl:          pgtype.Text{String: req.JobURL, Valid: req.JobURL != ""},
+		JobTitle:        pgtype.Text{String: req.JobTitle, Valid: req.JobTitle != ""},
+		CompanyName:     pgtype.Text{String: req.CompanyName, Valid: req.CompanyName != ""},
+		JobDescription:  pgtype.Text{String: req.JobDescription, Valid: true},
+		CvData:          cvData,
+		MatchScore:      pgtype.Int4{Int32: int32(analysis.MatchScore), Valid: true},
+		AiSuggestions:   analysisData,
+		TemplateID:      pgtype.Text{String: themes.DefaultID, Valid: true},
+		JobID:           jobID(job),
+		JobRequirements: requirementsData,
+		KeywordScore:    pgtype.Int4{Int32: int32(keywordMatch.Score), Valid: true},
+	}, cvSvc.SourceAIGeneration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save CV: %w", err)
 	}
 
-	// Increment credits used
-	updatedCredits, err := s.queries.IncrementCreditsUsed(ctx, userID)
-	if err != nil {
-		// Log the error but don't fail the request since CV is already saved
-		fmt.Printf("warning: failed to increment credits for user %s: %v\n", userID, err)
-	}
-
-	// Calculate remaining credits using the unified model
-	remaining := calculateRemainingCredits(updatedCredits)
-	if !updatedCredits.ID.Valid {
-		// Fallback if update failed
-		remaining = calculateRemainingCredits(credits)
-		remaining-- // Account for this generation
-	}
+	// The reserved credit is committed by settleCredit once we return successfully
+	reservation.CVID = savedCV.ID
+	remaining := credits.Remaining(reservation.Balance)
 
 	return &GenerateCVResponse{
 		CV: &CVData{
-			ID:         uuidToString(savedCV.ID),
-			Name:       savedCV.Name,
-			ResumeData: &tailoredResume,
-			MatchScore: analysis.MatchScore,
-			JobTitle:   req.JobTitle,
-			Company:    req.CompanyName,
-			CreatedAt:  timestampToString(savedCV.CreatedAt),
+			ID:           uuidToString(savedCV.ID),
+			Name:         savedCV.Name,
+			ResumeData:   tailoredResume,
+			MatchScore:   analysis.MatchScore,
+			KeywordScore: keywordMatch.Score,
+			JobID:        uuidToString(savedCV.JobID),
+			JobTitle:     req.JobTitle,
+			Company:      req.CompanyName,
+			CreatedAt:    timestampToString(savedCV.CreatedAt),
 		},
 		Analysis:         analysis,
+		Requirements:     requirements,
+		KeywordMatch:     keywordMatch,
+		Verification:     verification,
 		CreditsRemaining: int(remaining),
 	}, nil
 }
 
 // GetCredits returns the user's credit balance
 func (s *Service) GetCredits(ctx context.Context, userID string) (*CreditsResponse, error) {
-	credits, err := s.queries.GetOrCreateUserCredits(ctx, userID)
+	userCredits, err := s.queries.GetOrCreateUserCredits(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credits: %w", err)
 	}
 
 	return &CreditsResponse{
-		FreeGenerationsUsed:  credits.FreeGenerationsUsed,
-		FreeGenerationsLimit: credits.FreeGenerationsLimit,
-		PaidCredits:          credits.PaidCredits,
-		TotalGenerations:     credits.TotalGenerations,
-		Remaining:            calculateRemainingCredits(credits),
+		FreeGenerationsUsed:  userCredits.FreeGenerationsUsed,
+		FreeGenerationsLimit: userCredits.FreeGenerationsLimit,
+		PaidCredits:          userCredits.PaidCredits,
+		TotalGenerations:     userCredits.TotalGenerations,
+		Remaining:            credits.Remaining(userCredits),
 	}, nil
 }
 
 // GenerateCoverLetter generates a cover letter based on profile and job details
 func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *GenerateCoverLetterRequest) (*GenerateCoverLetterResponse, error) {
+	return s.generateCoverLetter(ctx, userID, req, nil)
+}
+
+// GenerateCoverLetterStream generates a cover letter like GenerateCoverLetter,
+// emitting the letter text as it is written
+func (s *Service) GenerateCoverLetterStream(ctx context.Context, userID string, req *GenerateCoverLetterRequest, emit EmitFunc) (*GenerateCoverLetterResponse, error) {
+	return s.generateCoverLetter(ctx, userID, req, emit)
+}
+
+// generateCoverLetter implements GenerateCoverLetter and GenerateCoverLetterStream; emit may be nil
+func (s *Service) generateCoverLetter(ctx context.Context, userID string, req *GenerateCoverLetterRequest, emit EmitFunc) (_ *GenerateCoverLetterResponse, err error) {
+	job, err := s.getJob(ctx, userID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		filled := *req
+		fillFromJob(job, &filled.JobTitle, &filled.CompanyName, nil, &filled.JobDescription)
+		req = &filled
+	}
+
 	if req.JobTitle == "" || req.CompanyName == "" {
-		return nil, errors.New("job_title and company_name are required")
+		return nil, ErrMissingJobDetails
 	}
 
-	// Check credits
-	credits, err := s.checkCredits(ctx, userID)
+	// Reserve a credit before calling the LLM; it is refunded if any later step fails
+	reservation, err := s.credits.Reserve(ctx, userID, credits.ReasonCoverLetter)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { s.settleCredit(ctx, reservation, err) }()
 
 	// Get user's profile
 	profileJSON, err := s.getProfileJSON(ctx, userID)
@@ -211,6 +343,7 @@ func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *G
 	jobDescription := req.JobDescription
 	cvSummary := ""
 	var cvIDPtr *string
+	savedJobID := jobID(job)
 
 	if req.CVID != "" {
 		// Get CV to use its job description and summary
@@ -231,6 +364,10 @@ func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *G
 				}
 				cvIDStr := uuidToString(cv.ID)
 				cvIDPtr = &cvIDStr
+				// A letter for a CV belongs to the job the CV was generated for
+				if !savedJobID.Valid {
+					savedJobID = cv.JobID
+				}
 			}
 		}
 	}
@@ -239,8 +376,12 @@ func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *G
 		jobDescription = fmt.Sprintf("Position: %s at %s", req.JobTitle, req.CompanyName)
 	}
 
+	if err := sendEvent(emit, EventWritingStarted, nil); err != nil {
+		return nil, err
+	}
+
 	// Generate cover letter
-	content, err := s.gemini.GenerateCoverLetter(ctx, profileJSON, req.JobTitle, req.CompanyName, jobDescription, cvSummary)
+	content, err := s.writeCoverLetter(ctx, profileJSON, req.JobTitle, req.CompanyName, jobDescription, cvSummary, chunkEmitter(emit))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate cover letter: %w", err)
 	}
@@ -257,21 +398,20 @@ func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *G
 		Content:     content,
 		JobTitle:    pgtype.Text{String: req.JobTitle, Valid: true},
 		CompanyName: pgtype.Text{String: req.CompanyName, Valid: true},
+		JobID:       savedJobID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to save cover letter: %w", err)
 	}
 
-	// Increment credits used
-	updatedCredits, err := s.queries.IncrementCreditsUsed(ctx, userID)
-	if err != nil {
-		fmt.Printf("warning: failed to increment credits for user %s: %v\n", userID, err)
-	}
+	// The reserved credit is committed by settleCredit once we return successfully
+	reservation.CoverLetterID = savedCL.ID
+	remaining := credits.Remaining(reservation.Balance)
 
-	remaining := calculateRemainingCredits(updatedCredits)
-	if !updatedCredits.ID.Valid {
-		remaining = calculateRemainingCredits(credits)
-		remaining--
+	var jobIDPtr *string
+	if savedCL.JobID.Valid {
+		jobIDStr := uuidToString(savedCL.JobID)
+		jobIDPtr = &jobIDStr
 	}
 
 	return &GenerateCoverLetterResponse{
@@ -281,64 +421,179 @@ func (s *Service) GenerateCoverLetter(ctx context.Context, userID string, req *G
 			JobTitle:    req.JobTitle,
 			CompanyName: req.CompanyName,
 			CVID:        cvIDPtr,
+			JobID:       jobIDPtr,
 			CreatedAt:   timestampToString(savedCL.CreatedAt),
 		},
 		CreditsRemaining: int(remaining),
 	}, nil
 }
 
-// checkCredits verifies the user has remaining credits
-func (s *Service) checkCredits(ctx context.Context, userID string) (db.UserCredit, error) {
-	credits, err := s.queries.GetOrCreateUserCredits(ctx, userID)
+// analyzeJob analyzes a job description against a candidate profile
+func (s *Service) analyzeJob(ctx context.Context, profileJSON string, jobDescription string) (*JobAnalysis, error) {
+	prompt := buildJobAnalysisPrompt(profileJSON, jobDescription)
+
+	var analysis JobAnalysis
+	err := s.generateDecoded(ctx, promptKindJobAnalysis, prompt, jobAnalysisSchema, nil, func(responseText string) error {
+		analysis = JobAnalysis{}
+		return json.Unmarshal([]byte(responseText), &analysis)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &analysis, nil
+}
+
+// tailorCV generates a tailored CV based on the profile and job description;
+// requirements may be nil. Partial output is streamed through emit when it is set.
+func (s *Service) tailorCV(ctx context.Context, profileJSON string, jobDescription string, analysis *JobAnalysis, requirements *models.JobRequirements, rules string, emit EmitFunc) (*models.JSONResume, error) {
+	analysisJSON, err := json.Marshal(analysis)
 	if err != nil {
-		return db.UserCredit{}, fmt.Errorf("failed to get credits: %w", err)
+		return nil, fmt.Errorf("failed to marshal analysis: %w", err)
 	}
 
-	// Calculate total remaining: free remaining + paid credits
-	remaining := calculateRemainingCredits(credits)
-	if remaining <= 0 {
-		return db.UserCredit{}, ErrOutOfCredits
+	var requirementsJSON []byte
+	if requirements != nil {
+		if requirementsJSON, err = json.Marshal(requirements); err != nil {
+			return nil, fmt.Errorf("failed to marshal job requirements: %w", err)
+		}
 	}
 
-	return credits, nil
+	prompt := buildCVTailoringPrompt(profileJSON, jobDescription, string(analysisJSON), string(requirementsJSON), rules)
+
+	var resume models.JSONResume
+	err = s.generateDecoded(ctx, promptKindCVTailoring, prompt, jsonResumeSchema, emit, func(responseText string) error {
+		resume = models.JSONResume{}
+		return json.Unmarshal([]byte(responseText), &resume)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resume, nil
 }
 
-// calculateRemainingCredits calculates total remaining credits (free + paid)
-func calculateRemainingCredits(credits db.UserCredit) int32 {
-	freeRemaining := credits.FreeGenerationsLimit - credits.FreeGenerationsUsed
-	if freeRemaining < 0 {
-		freeRemaining = 0
+// generateDecoded generates schema constrained content and decodes it, regenerating
+// up to RetryPolicy.InvalidJSONRetries times when the response cannot be decoded.
+// When emit is set, partial output is streamed and an EventRetry precedes each regeneration.
+func (s *Service) generateDecoded(ctx context.Context, kind promptKind, prompt string, schema map[string]interface{}, emit EmitFunc, decode func(responseText string) error) error {
+	var decodeErr error
+	for attempt := 0; attempt <= s.retry.InvalidJSONRetries; attempt++ {
+		if attempt > 0 {
+			if err := sendEvent(emit, EventRetry, nil); err != nil {
+				return err
+			}
+		}
+
+		responseText, err := generateJSON(withPromptKind(ctx, kind), s.llm, prompt, schema, chunkEmitter(emit))
+		if err != nil {
+			return err
+		}
+
+		if decodeErr = decode(responseText); decodeErr == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %v", ErrInvalidResponse, decodeErr)
+}
+
+// writeCoverLetter generates a cover letter based on the profile and job details.
+// Partial output is streamed to onChunk when it is set.
+func (s *Service) writeCoverLetter(ctx context.Context, profileJSON string, jobTitle string, companyName string, jobDescription string, cvSummary string, onChunk ChunkFunc) (string, error) {
+	prompt := buildCoverLetterPrompt(profileJSON, jobTitle, companyName, jobDescription, cvSummary)
+
+	return generateText(withPromptKind(ctx, promptKindCoverLetter), s.llm, prompt, onChunk)
+}
+
+// sendEvent emits a progress event if streaming is enabled
+func sendEvent(emit EmitFunc, eventType string, data interface{}) error {
+	if emit == nil {
+		return nil
+	}
+	return emit(StreamEvent{Type: eventType, Data: data})
+}
+
+// chunkEmitter adapts an EmitFunc to a ChunkFunc, returning nil when streaming is disabled
+func chunkEmitter(emit EmitFunc) ChunkFunc {
+	if emit == nil {
+		return nil
+	}
+	return func(chunk string) error {
+		return emit(StreamEvent{Type: EventChunk, Data: ChunkData{Text: chunk}})
 	}
-	return freeRemaining + credits.PaidCredits
+}
+
+// settleCredit commits a reserved credit after a successful generation or
+// refunds it when the generation failed
+func (s *Service) settleCredit(ctx context.Context, reservation *credits.Reservation, genErr error) {
+	// Settle even if the client disconnected and cancelled the request context
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), creditReleaseTimeout)
+	defer cancel()
+
+	if genErr == nil {
+		if err := s.credits.Commit(ctx, reservation); err != nil {
+			log.Printf("ERROR: failed to commit credit for user %s: %v", reservation.UserID, err)
+		}
+		return
+	}
+
+	if err := s.credits.Release(ctx, reservation); err != nil {
+		log.Printf("ERROR: failed to refund credit for user %s after failed generation (%v): %v", reservation.UserID, genErr, err)
+	}
+}
+
+// profileSnapshot is the user's profile as read for one request
+type profileSnapshot struct {
+	resume *models.JSONResume
+	// json is the profile serialized for the AI prompt
+	json string
+	// version is the latest version of the profile; profiles saved before
+	// version history existed have none
+	version pgtype.Int4
 }
 
 // getProfileJSON retrieves and serializes the user's profile
 func (s *Service) getProfileJSON(ctx context.Context, userID string) (string, error) {
-	profile, err := s.queries.GetMasterProfile(ctx, userID)
+	snapshot, err := s.getProfile(ctx, userID)
 	if err != nil {
-		return "", ErrProfileNotFound
+		return "", err
+	}
+	return snapshot.json, nil
+}
+
+// getProfile retrieves the user's profile, both parsed and serialized for the
+// AI prompt, together with the version it was read at
+func (s *Service) getProfile(ctx context.Context, userID string) (*profileSnapshot, error) {
+	profile, err := s.queries.GetMasterProfileWithVersion(ctx, userID)
+	if err != nil {
+		return nil, ErrProfileNotFound
 	}
 
 	// Parse the stored resume data
 	var resumeData models.JSONResume
 	if len(profile.ResumeData) > 0 {
 		if err := json.Unmarshal(profile.ResumeData, &resumeData); err != nil {
-			return "", fmt.Errorf("failed to parse profile data: %w", err)
+			return nil, fmt.Errorf("failed to parse profile data: %w", err)
 		}
 	}
 
 	// Check if profile has meaningful content
 	if resumeData.Basics == nil || resumeData.Basics.Name == "" {
-		return "", ErrProfileNotFound
+		return nil, ErrProfileNotFound
 	}
 
 	// Re-serialize for the AI prompt (formatted JSON)
 	profileJSON, err := json.MarshalIndent(&resumeData, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize profile: %w", err)
+		return nil, fmt.Errorf("failed to serialize profile: %w", err)
 	}
 
-	return string(profileJSON), nil
+	return &profileSnapshot{
+		resume:  &resumeData,
+		json:    string(profileJSON),
+		version: profile.Version,
+	}, nil
 }
 
 // Helper functions
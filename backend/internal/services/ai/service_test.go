@@ -0,0 +1,609 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/services/credits"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const testUserID = "user_test"
+
+// fakeQueries is an in-memory db.Querier and CVStore covering the queries used
+// by Service. Calling any other query panics via the nil embedded interface.
+type fakeQueries struct {
+	db.Querier
+
+	profile        []byte
+	profileVersion int32
+	cvs            []db.CreateCVParams
+	revisions      []db.CreateCVRevisionParams
+	coverLetters   []db.CreateCoverLetterParams
+	jobs           []db.Job
+	// analyses caches job analyses keyed by job, profile version and description hash
+	analyses map[string][]byte
+}
+
+func newFakeQueries(t *testing.T) *fakeQueries {
+	t.Helper()
+	return &fakeQueries{
+		profile:        readTestdata(t, "profile.json"),
+		profileVersion: 1,
+		analyses:       map[string][]byte{},
+	}
+}
+
+// addJob saves a job for the test user and returns its ID
+func (f *fakeQueries) addJob(title, company, description string) string {
+	id := pgtype.UUID{Bytes: [16]byte{4, byte(len(f.jobs))}, Valid: true}
+	f.jobs = append(f.jobs, db.Job{
+		ID:          id,
+		UserID:      testUserID,
+		Title:       pgtype.Text{String: title, Valid: title != ""},
+		CompanyName: pgtype.Text{String: company, Valid: company != ""},
+		Description: description,
+	})
+	return uuidToString(id)
+}
+
+func (f *fakeQueries) GetJobByUserAndId(ctx context.Context, arg db.GetJobByUserAndIdParams) (db.Job, error) {
+	for _, job := range f.jobs {
+		if job.ID == arg.ID && job.UserID == arg.UserID {
+			return job, nil
+		}
+	}
+	return db.Job{}, pgx.ErrNoRows
+}
+
+func (f *fakeQueries) GetJobAnalysis(ctx context.Context, arg db.GetJobAnalysisParams) (db.JobAnalysis, error) {
+	data, ok := f.analyses[fmt.Sprintf("%x/%d/%s", arg.JobID.Bytes, arg.ProfileVersion, arg.DescriptionHash)]
+	if !ok {
+		return db.JobAnalysis{}, pgx.ErrNoRows
+	}
+	return db.JobAnalysis{JobID: arg.JobID, ProfileVersion: arg.ProfileVersion, Analysis: data}, nil
+}
+
+func (f *fakeQueries) UpsertJobAnalysis(ctx context.Context, arg db.UpsertJobAnalysisParams) (db.JobAnalysis, error) {
+	f.analyses[fmt.Sprintf("%x/%d/%s", arg.JobID.Bytes, arg.ProfileVersion, arg.DescriptionHash)] = arg.Analysis
+	return db.JobAnalysis{JobID: arg.JobID, ProfileVersion: arg.ProfileVersion, Analysis: arg.Analysis}, nil
+}
+
+func (f *fakeQueries) GetMasterProfileWithVersion(ctx context.Context, userID string) (db.GetMasterProfileWithVersionRow, error) {
+	if f.profile == nil {
+		return db.GetMasterProfileWithVersionRow{}, pgx.ErrNoRows
+	}
+	return db.GetMasterProfileWithVersionRow{
+		UserID:     userID,
+		ResumeData: f.profile,
+		Version:    pgtype.Int4{Int32: f.profileVersion, Valid: f.profileVersion > 0},
+	}, nil
+}
+
+func (f *fakeQueries) CreateWithRevision(ctx context.Context, arg db.CreateCVParams, source string) (db.GeneratedCv, error) {
+	f.cvs = append(f.cvs, arg)
+	cv := db.GeneratedCv{
+		ID:     pgtype.UUID{Bytes: [16]byte{2}, Valid: true},
+		UserID: arg.UserID,
+		Name:   arg.Name,
+		CvData: arg.CvData,
+		JobID:  arg.JobID,
+	}
+	f.revisions = append(f.revisions, db.CreateCVRevisionParams{CvID: cv.ID, UserID: cv.UserID, Source: source})
+	return cv, nil
+}
+
+func (f *fakeQueries) GetCVByUserAndId(ctx context.Context, arg db.GetCVByUserAndIdParams) (db.GeneratedCv, error) {
+	return db.GeneratedCv{}, pgx.ErrNoRows
+}
+
+func (f *fakeQueries) CreateCoverLetter(ctx context.Context, arg db.CreateCoverLetterParams) (db.CoverLetter, error) {
+	f.coverLetters = append(f.coverLetters, arg)
+	return db.CoverLetter{
+		ID:      pgtype.UUID{Bytes: [16]byte{3}, Valid: true},
+		UserID:  arg.UserID,
+		Content: arg.Content,
+	}, nil
+}
+
+// fakeCredits is an in-memory CreditStore that tracks reservations
+type fakeCredits struct {
+	balance   db.UserCredit
+	reserved  int
+	committed int
+	released  int
+	// lastCommitted is the most recently committed reservation
+	lastCommitted *credits.Reservation
+}
+
+func newFakeCredits() *fakeCredits {
+	return &fakeCredits{
+		balance: db.UserCredit{UserID: testUserID, FreeGenerationsLimit: 10},
+	}
+}
+
+func (f *fakeCredits) Reserve(ctx context.Context, userID, reason string) (*credits.Reservation, error) {
+	if credits.Remaining(f.balance) <= 0 {
+		return nil, credits.ErrOutOfCredits
+	}
+	f.reserved++
+	f.balance.FreeGenerationsUsed++
+	f.balance.TotalGenerations++
+	return &credits.Reservation{UserID: userID, Source: credits.SourceFree, Balance: f.balance}, nil
+}
+
+func (f *fakeCredits) Commit(ctx context.Context, reservation *credits.Reservation) error {
+	f.committed++
+	f.lastCommitted = reservation
+	return nil
+}
+
+func (f *fakeCredits) Release(ctx context.Context, reservation *credits.Reservation) error {
+	f.released++
+	f.balance.FreeGenerationsUsed--
+	f.balance.TotalGenerations--
+	return nil
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read testdata %s: %v", name, err)
+	}
+	return data
+}
+
+// assertGolden compares got with testdata/golden/<name>.golden, rewriting it with -update
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with -update to create): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s does not match golden file %s\n--- got ---\n%s", name, path, got)
+	}
+}
+
+func jobDescription(t *testing.T) string {
+	t.Helper()
+	return strings.TrimSpace(string(readTestdata(t, "job.txt")))
+}
+
+func TestServiceAnalyzeJob(t *testing.T) {
+	llm := NewFakeProvider().Script(promptKindJobAnalysis, FakeResponse{Text: string(readTestdata(t, "analysis.json"))})
+	svc := NewWithProvider(llm, newFakeQueries(t), newFakeCredits(), nil)
+
+	analysis, err := svc.AnalyzeJob(context.Background(), testUserID, &AnalyzeJobRequest{JobDescription: jobDescription(t)})
+	if err != nil {
+		t.Fatalf("AnalyzeJob: %v", err)
+	}
+	if analysis.MatchScore != 82 || len(analysis.MatchingSkills) != 3 {
+		t.Errorf("unexpected analysis %+v", analysis)
+	}
+
+	calls := llm.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 LLM call, got %d", len(calls))
+	}
+	if calls[0].Schema == nil {
+		t.Error("expected job analysis to be schema constrained")
+	}
+	assertGolden(t, "job_analysis_prompt", calls[0].Prompt)
+}
+
+func TestServiceGenerateCV(t *testing.T) {
+	llm := NewFakeProvider().
+		Script(promptKindJobAnalysis, FakeResponse{Text: string(readTestdata(t, "analysis.json"))}).
+		Script(promptKindJobRequirements, FakeResponse{Text: string(readTestdata(t, "requirements.json"))}).
+		Script(promptKindCVTailoring, FakeResponse{Text: string(readTestdata(t, "tailored_cv.json"))})
+	queries := newFakeQueries(t)
+	creditStore := newFakeCredits()
+	svc := NewWithProvider(llm, queries, creditStore, queries)
+
+	resp, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{
+		JobDescription: jobDescription(t),
+		JobTitle:       "Staff Backend Engineer",
+		CompanyName:    "Globex",
+	})
+	if err != nil {
+		t.Fatalf("GenerateCV: %v", err)
+	}
+
+	if resp.CV.Name != "Staff Backend Engineer CV" {
+		t.Errorf("unexpected CV name %q", resp.CV.Name)
+	}
+	if resp.CV.MatchScore != 82 {
+		t.Errorf("unexpected match score %d", resp.CV.MatchScore)
+	}
+	if resp.CreditsRemaining != 9 {
+		t.Errorf("expected 9 credits remaining, got %d", resp.CreditsRemaining)
+	}
+	if len(queries.cvs) != 1 {
+		t.Fatalf("expected 1 saved CV, got %d", len(queries.cvs))
+	}
+	if resp.Verification == nil || len(resp.Verification.Findings) != 0 {
+		t.Errorf("expected the tailored CV to pass verification, got %+v", resp.Verification)
+	}
+	if len(queries.revisions) != 1 || queries.revisions[0].Source != "ai_generation" {
+		t.Errorf("expected the saved CV to get an ai_generation revision, got %+v", queries.revisions)
+	}
+	if creditStore.committed != 1 || creditStore.released != 0 {
+		t.Errorf("expected the reserved credit to be committed, committed %d released %d", creditStore.committed, creditStore.released)
+	}
+	if creditStore.lastCommitted == nil || !creditStore.lastCommitted.CVID.Valid {
+		t.Error("expected the committed credit to reference the saved CV")
+	}
+
+	if resp.Requirements == nil || len(resp.Requirements.MustHave) != 2 {
+		t.Fatalf("expected the parsed requirements, got %+v", resp.Requirements)
+	}
+	if resp.Requirements.RemotePolicy != "" || resp.Requirements.Seniority != "staff" || resp.Requirements.Salary != nil {
+		t.Errorf("expected the requirements to be normalized, got %+v", resp.Requirements)
+	}
+	if !resp.Requirements.MustHave[0].Covered || resp.Requirements.MustHave[1].Covered {
+		t.Errorf("expected only the Go and PostgreSQL requirement to be covered, got %+v", resp.Requirements.MustHave)
+	}
+	if len(queries.cvs[0].JobRequirements) == 0 {
+		t.Error("expected the requirements to be saved with the CV")
+	}
+
+	if resp.KeywordMatch == nil || len(resp.KeywordMatch.Matched) == 0 {
+		t.Fatalf("expected a keyword match, got %+v", resp.KeywordMatch)
+	}
+	if saved := queries.cvs[0].KeywordScore; !saved.Valid || int(saved.Int32) != resp.KeywordMatch.Score || resp.CV.KeywordScore != resp.KeywordMatch.Score {
+		t.Errorf("expected keyword score %d to be saved and returned, got %+v and %d", resp.KeywordMatch.Score, saved, resp.CV.KeywordScore)
+	}
+
+	calls := llm.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 LLM calls, got %d", len(calls))
+	}
+	if calls[0].Kind != promptKindJobAnalysis || calls[1].Kind != promptKindJobRequirements || calls[2].Kind != promptKindCVTailoring {
+		t.Fatalf("unexpected call order %s, %s, %s", calls[0].Kind, calls[1].Kind, calls[2].Kind)
+	}
+	if calls[1].Schema == nil {
+		t.Error("expected requirements parsing to be schema constrained")
+	}
+	assertGolden(t, "job_requirements_prompt", calls[1].Prompt)
+	assertGolden(t, "cv_tailoring_prompt", calls[2].Prompt)
+	assertGolden(t, "generated_cv_data", string(queries.cvs[0].CvData))
+}
+
+func TestServiceGenerateCVWithoutRequirements(t *testing.T) {
+	llm := NewFakeProvider().
+		Script(promptKindJobAnalysis, FakeResponse{Text: string(readTestdata(t, "analysis.json"))}).
+		Script(promptKindJobRequirements, FakeResponse{Text: `{"must_have": [`}).
+		Script(promptKindCVTailoring, FakeResponse{Text: string(readTestdata(t, "tailored_cv.json"))})
+	queries := newFakeQueries(t)
+	svc := NewWithProvider(llm, queries, newFakeCredits(), queries)
+
+	resp, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobDescription: jobDescription(t)})
+	if err != nil {
+		t.Fatalf("expected a failed requirements parse not to fail the generation, got %v", err)
+	}
+	if resp.Requirements != nil || queries.cvs[0].JobRequirements != nil {
+		t.Errorf("expected no requirements, got %+v", resp.Requirements)
+	}
+	for _, call := range llm.Calls() {
+		if call.Kind == promptKindCVTailoring && strings.Contains(call.Prompt, "Job Requirements") {
+			t.Error("expected the tailoring prompt to leave out the requirements")
+		}
+	}
+}
+
+func TestServiceGenerateCoverLetter(t *testing.T) {
+	llm := NewFakeProvider().Script(promptKindCoverLetter, FakeResponse{Text: "Globex needs a payments lead. I led billing at Acme."})
+	queries := newFakeQueries(t)
+	svc := NewWithProvider(llm, queries, newFakeCredits(), queries)
+
+	resp, err := svc.GenerateCoverLetter(context.Background(), testUserID, &GenerateCoverLetterRequest{
+		JobTitle:       "Staff Backend Engineer",
+		CompanyName:    "Globex",
+		JobDescription: jobDescription(t),
+	})
+	if err != nil {
+		t.Fatalf("GenerateCoverLetter: %v", err)
+	}
+	if resp.CoverLetter.Content != "Globex needs a payments lead. I led billing at Acme." {
+		t.Errorf("unexpected content %q", resp.CoverLetter.Content)
+	}
+	if len(queries.coverLetters) != 1 {
+		t.Fatalf("expected 1 saved cover letter, got %d", len(queries.coverLetters))
+	}
+
+	calls := llm.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 LLM call, got %d", len(calls))
+	}
+	if calls[0].Schema != nil {
+		t.Error("expected cover letter to be generated as plain text")
+	}
+	assertGolden(t, "cover_letter_prompt", calls[0].Prompt)
+}
+
+func TestServiceMalformedResponses(t *testing.T) {
+	analysis := FakeResponse{Text: string(readTestdata(t, "analysis.json"))}
+	malformed := FakeResponse{Text: `{"match_score": "eighty`}
+
+	tests := []struct {
+		name     string
+		scripted map[promptKind]FakeResponse
+		run      func(svc *Service) error
+	}{
+		{
+			name:     "analyze job",
+			scripted: map[promptKind]FakeResponse{promptKindJobAnalysis: malformed},
+			run: func(svc *Service) error {
+				_, err := svc.AnalyzeJob(context.Background(), testUserID, &AnalyzeJobRequest{JobDescription: "Go engineer"})
+				return err
+			},
+		},
+		{
+			name:     "generate cv analysis",
+			scripted: map[promptKind]FakeResponse{promptKindJobAnalysis: malformed},
+			run: func(svc *Service) error {
+				_, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobDescription: "Go engineer"})
+				return err
+			},
+		},
+		{
+			name: "generate cv tailoring",
+			scripted: map[promptKind]FakeResponse{
+				promptKindJobAnalysis: analysis,
+				promptKindCVTailoring: malformed,
+			},
+			run: func(svc *Service) error {
+				_, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobDescription: "Go engineer"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llm := NewFakeProvider()
+			for kind, response := range tt.scripted {
+				llm.Script(kind, response)
+			}
+			queries := newFakeQueries(t)
+			creditStore := newFakeCredits()
+
+			err := tt.run(NewWithProvider(llm, queries, creditStore, queries))
+			if !errors.Is(err, ErrInvalidResponse) {
+				t.Fatalf("expected ErrInvalidResponse, got %v", err)
+			}
+			if len(queries.cvs) != 0 {
+				t.Error("expected no CV to be saved")
+			}
+			if creditStore.released != creditStore.reserved {
+				t.Errorf("expected reserved credits to be released, reserved %d released %d", creditStore.reserved, creditStore.released)
+			}
+			if creditStore.committed != 0 || creditStore.balance.TotalGenerations != 0 {
+				t.Error("expected no credits to be used")
+			}
+		})
+	}
+}
+
+func TestServiceGenerateCVStream(t *testing.T) {
+	tailored := string(readTestdata(t, "tailored_cv.json"))
+	llm := NewFakeProvider().
+		Script(promptKindJobAnalysis, FakeResponse{Text: string(readTestdata(t, "analysis.json"))}).
+		Script(promptKindJobRequirements, FakeResponse{Text: string(readTestdata(t, "requirements.json"))}).
+		Script(promptKindCVTailoring, FakeResponse{Text: tailored})
+	queries := newFakeQueries(t)
+	svc := NewWithProvider(llm, queries, newFakeCredits(), queries)
+
+	var events []StreamEvent
+	resp, err := svc.GenerateCVStream(context.Background(), testUserID, &GenerateCVRequest{
+		JobDescription: jobDescription(t),
+	}, func(event StreamEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GenerateCVStream: %v", err)
+	}
+	if resp.CV == nil || len(queries.cvs) != 1 {
+		t.Fatal("expected the streamed CV to be saved")
+	}
+
+	wantTypes := []string{EventAnalysisDone, EventRequirementsDone, EventTailoringStarted, EventChunk, EventVerificationDone}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("expected %d events, got %+v", len(wantTypes), events)
+	}
+	for i, want := range wantTypes {
+		if events[i].Type != want {
+			t.Errorf("event %d: expected %s, got %s", i, want, events[i].Type)
+		}
+	}
+	if chunk, ok := events[3].Data.(ChunkData); !ok || chunk.Text != tailored {
+		t.Errorf("unexpected chunk data %+v", events[3].Data)
+	}
+}
+
+func TestServiceOutOfCredits(t *testing.T) {
+	llm := NewFakeProvider()
+	creditStore := newFakeCredits()
+	creditStore.balance.FreeGenerationsUsed = creditStore.balance.FreeGenerationsLimit
+	svc := NewWithProvider(llm, newFakeQueries(t), creditStore, nil)
+
+	_, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobDescription: "Go engineer"})
+	if !errors.Is(err, ErrOutOfCredits) {
+		t.Fatalf("expected ErrOutOfCredits, got %v", err)
+	}
+	if calls := len(llm.Calls()); calls != 0 {
+		t.Errorf("expected no LLM calls without credits, got %d", calls)
+	}
+}
+
+func TestServiceRetriesInvalidJSON(t *testing.T) {
+	llm := NewFakeProvider().Script(promptKindJobAnalysis,
+		FakeResponse{Text: `{"match_score": `},
+		FakeResponse{Text: string(readTestdata(t, "analysis.json"))},
+	)
+	svc := NewWithProvider(llm, newFakeQueries(t), newFakeCredits(), nil)
+
+	analysis, err := svc.AnalyzeJob(context.Background(), testUserID, &AnalyzeJobRequest{JobDescription: "Go engineer"})
+	if err != nil {
+		t.Fatalf("AnalyzeJob: %v", err)
+	}
+	if analysis.MatchScore != 82 {
+		t.Errorf("unexpected match score %d", analysis.MatchScore)
+	}
+	if calls := len(llm.Calls()); calls != 2 {
+		t.Errorf("expected 2 LLM calls, got %d", calls)
+	}
+}
+
+func TestServiceGenerateCVLockedFields(t *testing.T) {
+	llm := NewFakeProvider().
+		Script(promptKindJobAnalysis, FakeResponse{Text: string(readTestdata(t, "analysis.json"))}).
+		Script(promptKindJobRequirements, FakeResponse{Text: string(readTestdata(t, "requirements.json"))}).
+		Script(promptKindCVTailoring, FakeResponse{Text: string(readTestdata(t, "tailored_cv.json"))})
+	queries := newFakeQueries(t)
+	svc := NewWithProvider(llm, queries, newFakeCredits(), queries)
+
+	resp, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{
+		JobDescription:  jobDescription(t),
+		ExcludeSections: []string{"skills"},
+		LockedFields:    []string{"basics.summary", "work[1]"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateCV: %v", err)
+	}
+
+	resume := resp.CV.ResumeData
+	if resume.Basics.Summary != "Backend engineer with six years of experience building Go services." {
+		t.Errorf("expected the locked summary to be restored, got %q", resume.Basics.Summary)
+	}
+	if len(resume.Work) != 2 || resume.Work[1].Name != "Widgets GmbH" || len(resume.Work[1].Highlights) != 1 {
+		t.Errorf("expected the dropped locked position to be put back, got %+v", resume.Work)
+	}
+	if len(resume.Work[0].Highlights) != 1 {
+		t.Errorf("expected the unlocked position to stay tailored, got %+v", resume.Work[0])
+	}
+	if len(resume.Skills) != 0 {
+		t.Errorf("expected the excluded skills section to be dropped, got %+v", resume.Skills)
+	}
+
+	prompt := llm.Calls()[2].Prompt
+	for _, want := range []string{"- Only include these sections: basics, work, volunteer, education", "- basics.summary\n- work[1]\n"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected the tailoring prompt to contain %q", want)
+		}
+	}
+	if strings.Contains(prompt, `"skills":`) {
+		t.Error("expected the excluded skills to be left out of the tailoring prompt")
+	}
+}
+
+func TestServiceGenerateCVInvalidTailoring(t *testing.T) {
+	tests := []struct {
+		name string
+		req  GenerateCVRequest
+	}{
+		{"unknown section", GenerateCVRequest{IncludeSections: []string{"hobbies"}}},
+		{"nothing left", GenerateCVRequest{IncludeSections: []string{"work"}, ExcludeSections: []string{"work"}}},
+		{"bad path", GenerateCVRequest{LockedFields: []string{"work.highlights"}}},
+		{"locked but excluded", GenerateCVRequest{ExcludeSections: []string{"work"}, LockedFields: []string{"work[0]"}}},
+		{"not in profile", GenerateCVRequest{LockedFields: []string{"projects[0]"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creditStore := newFakeCredits()
+			svc := NewWithProvider(NewFakeProvider(), newFakeQueries(t), creditStore, nil)
+
+			tt.req.JobDescription = jobDescription(t)
+			if _, err := svc.GenerateCV(context.Background(), testUserID, &tt.req); !errors.Is(err, ErrInvalidTailoring) {
+				t.Fatalf("expected ErrInvalidTailoring, got %v", err)
+			}
+			if creditStore.balance.FreeGenerationsUsed != 0 {
+				t.Error("expected no credit to be spent")
+			}
+		})
+	}
+}
+
+func TestServiceGenerateCVForSavedJob(t *testing.T) {
+	analysis := FakeResponse{Text: string(readTestdata(t, "analysis.json"))}
+	tailored := FakeResponse{Text: string(readTestdata(t, "tailored_cv.json"))}
+	llm := NewFakeProvider().
+		Script(promptKindJobAnalysis, analysis, analysis).
+		Script(promptKindCVTailoring, tailored, tailored, tailored)
+	queries := newFakeQueries(t)
+	svc := NewWithProvider(llm, queries, newFakeCredits(), queries)
+	jobID := queries.addJob("Staff Backend Engineer", "Globex", jobDescription(t))
+
+	analysisCalls := func() int {
+		n := 0
+		for _, call := range llm.Calls() {
+			if call.Kind == promptKindJobAnalysis {
+				n++
+			}
+		}
+		return n
+	}
+
+	resp, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobID: jobID})
+	if err != nil {
+		t.Fatalf("GenerateCV: %v", err)
+	}
+	if resp.CV.Name != "Staff Backend Engineer CV" || resp.CV.JobID != jobID {
+		t.Errorf("expected the CV to be named after and linked to the job, got %+v", resp.CV)
+	}
+	if saved := queries.cvs[0]; saved.CompanyName.String != "Globex" || saved.JobDescription.String != jobDescription(t) {
+		t.Errorf("expected the job details to be saved with the CV, got %+v", saved)
+	}
+
+	// Generating again for the same job and profile reuses the analysis
+	if _, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobID: jobID}); err != nil {
+		t.Fatalf("GenerateCV again: %v", err)
+	}
+	if n := analysisCalls(); n != 1 {
+		t.Errorf("expected the cached analysis to be reused, got %d analysis calls", n)
+	}
+
+	// A new profile version needs a new analysis
+	queries.profileVersion++
+	if _, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobID: jobID}); err != nil {
+		t.Fatalf("GenerateCV after profile edit: %v", err)
+	}
+	if n := analysisCalls(); n != 2 {
+		t.Errorf("expected the job to be analyzed again after a profile edit, got %d analysis calls", n)
+	}
+
+	// So does a new description, even with an analysis of the old one cached
+	queries.jobs[0].Description = jobDescription(t) + "\nMust know Kafka."
+	if _, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobID: jobID}); err != nil {
+		t.Fatalf("GenerateCV after job edit: %v", err)
+	}
+	if n := analysisCalls(); n != 3 {
+		t.Errorf("expected the job to be analyzed again after its description changed, got %d analysis calls", n)
+	}
+
+	if _, err := svc.GenerateCV(context.Background(), testUserID, &GenerateCVRequest{JobID: "00000000-0000-0000-0000-000000000000"}); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"cv-gen/backend/internal/models"
+)
+
+// ErrInvalidTailoring is returned when the sections or locked fields of a
+// GenerateCVRequest are invalid
+var ErrInvalidTailoring = errors.New("invalid tailoring options")
+
+// tailoring holds the section selection and locked fields of a GenerateCVRequest
+type tailoring struct {
+	// sections the tailored CV may contain, in models.ValidSections order
+	sections []string
+	// selected is set when the request narrowed down the sections
+	selected bool
+	locked   []models.FieldPath
+}
+
+// newTailoring validates the section selection and locked fields of a request
+func newTailoring(req *GenerateCVRequest) (*tailoring, error) {
+	for _, section := range append(append([]string{}, req.IncludeSections...), req.ExcludeSections...) {
+		if !models.IsValidSection(section) {
+			return nil, fmt.Errorf("%w: unknown section %q", ErrInvalidTailoring, section)
+		}
+	}
+
+	t := &tailoring{selected: len(req.IncludeSections) > 0 || len(req.ExcludeSections) > 0}
+	for _, section := range models.ValidSections() {
+		if len(req.IncludeSections) > 0 && !contains(req.IncludeSections, section) {
+			continue
+		}
+		if contains(req.ExcludeSections, section) {
+			continue
+		}
+		t.sections = append(t.sections, section)
+	}
+	if len(t.sections) == 0 {
+		return nil, fmt.Errorf("%w: no sections left to include", ErrInvalidTailoring)
+	}
+
+	for _, field := range req.LockedFields {
+		path, err := models.ParseFieldPath(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTailoring, err)
+		}
+		if !contains(t.sections, path.Section) {
+			return nil, fmt.Errorf("%w: locked field %q is in an excluded section", ErrInvalidTailoring, field)
+		}
+		t.locked = append(t.locked, path)
+	}
+
+	return t, nil
+}
+
+// checkProfile makes sure every locked field exists in the master profile
+func (t *tailoring) checkProfile(profile *models.JSONResume) error {
+	for _, path := range t.locked {
+		if !models.HasField(profile, path) {
+			return fmt.Errorf("%w: locked field %q is not in your profile", ErrInvalidTailoring, path)
+		}
+	}
+	return nil
+}
+
+// profileJSON returns the profile as shown to the AI for tailoring: only the
+// selected sections, formatted like getProfile formats the whole profile
+func (t *tailoring) profileJSON(profile *models.JSONResume, profileJSON string) (string, error) {
+	if !t.selected {
+		return profileJSON, nil
+	}
+	data, err := json.MarshalIndent(models.SelectSections(profile, t.sections), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize profile: %w", err)
+	}
+	return string(data), nil
+}
+
+// promptRules renders the section selection and locked fields as rules for
+// the tailoring prompt
+func (t *tailoring) promptRules() string {
+	var sections []string
+	if t.selected {
+		sections = t.sections
+	}
+	locked := make([]string, 0, len(t.locked))
+	for _, path := range t.locked {
+		locked = append(locked, path.String())
+	}
+	return buildTailoringRules(sections, locked)
+}
+
+// apply drops the sections that were not selected from a tailored CV and
+// restores locked fields from the master profile
+func (t *tailoring) apply(profile, tailored *models.JSONResume) (*models.JSONResume, error) {
+	resume := tailored
+	if t.selected {
+		resume = models.SelectSections(resume, t.sections)
+	}
+	if len(t.locked) == 0 {
+		return resume, nil
+	}
+
+	resume, err := models.RestoreFields(profile, resume, t.locked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore locked fields: %w", err)
+	}
+	return resume, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, element := range list {
+		if element == s {
+			return true
+		}
+	}
+	return false
+}
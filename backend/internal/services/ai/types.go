@@ -1,7 +1,10 @@
 // Package ai provides AI-powered services for CV generation and job analysis
 package ai
 
-import "cv-gen/backend/internal/models"
+import (
+	"cv-gen/backend/internal/ats"
+	"cv-gen/backend/internal/models"
+)
 
 // JobAnalysis represents the result of analyzing a job description against a profile
 type JobAnalysis struct {
@@ -16,6 +19,8 @@ type JobAnalysis struct {
 // AnalyzeJobRequest represents a request to analyze a job description
 type AnalyzeJobRequest struct {
 	JobDescription string `json:"job_description"`
+	// JobID analyzes a saved job; its description is used when JobDescription is empty
+	JobID string `json:"job_id,omitempty"`
 }
 
 // AnalyzeJobResponse represents the response from job analysis
@@ -30,13 +35,35 @@ type GenerateCVRequest struct {
 	JobTitle       string `json:"job_title,omitempty"`
 	CompanyName    string `json:"company_name,omitempty"`
 	JobURL         string `json:"job_url,omitempty"`
+	// JobID generates the CV for a saved job; its title, company, URL and
+	// description fill in the fields above that are left empty
+	JobID string `json:"job_id,omitempty"`
+	// IncludeSections limits the tailored CV to these sections; all sections when empty
+	IncludeSections []string `json:"include_sections,omitempty"`
+	// ExcludeSections leaves these sections out of the tailored CV
+	ExcludeSections []string `json:"exclude_sections,omitempty"`
+	// LockedFields are paths into the master profile, such as "basics.summary" or
+	// "projects[1]", whose content is copied into the tailored CV unchanged
+	LockedFields []string `json:"locked_fields,omitempty"`
 }
 
 // GenerateCVResponse represents the response from CV generation
 type GenerateCVResponse struct {
-	CV               *CVData      `json:"cv"`
-	Analysis         *JobAnalysis `json:"analysis"`
-	CreditsRemaining int          `json:"credits_remaining"`
+	CV       *CVData      `json:"cv"`
+	Analysis *JobAnalysis `json:"analysis"`
+	// Requirements is nil when the job description could not be parsed
+	Requirements *models.JobRequirements `json:"requirements,omitempty"`
+	// KeywordMatch is the deterministic keyword score of the CV against the job description
+	KeywordMatch     *ats.Result          `json:"keyword_match"`
+	Verification     *models.Verification `json:"verification"`
+	CreditsRemaining int                  `json:"credits_remaining"`
+}
+
+// storedSuggestions is what a generated CV stores in ai_suggestions: the job
+// analysis with the verification findings next to it
+type storedSuggestions struct {
+	*JobAnalysis
+	Verification *models.Verification `json:"verification,omitempty"`
 }
 
 // CVData represents a generated CV
@@ -45,9 +72,12 @@ type CVData struct {
 	Name       string             `json:"name"`
 	ResumeData *models.JSONResume `json:"resume_data"`
 	MatchScore int                `json:"match_score"`
-	JobTitle   string             `json:"job_title,omitempty"`
-	Company    string             `json:"company,omitempty"`
-	CreatedAt  string             `json:"created_at"`
+	// KeywordScore is the deterministic keyword score next to the AI's MatchScore
+	KeywordScore int    `json:"keyword_score"`
+	JobID        string `json:"job_id,omitempty"`
+	JobTitle     string `json:"job_title,omitempty"`
+	Company      string `json:"company,omitempty"`
+	CreatedAt    string `json:"created_at"`
 }
 
 // CreditsResponse represents the user's credit balance
@@ -65,6 +95,9 @@ type GenerateCoverLetterRequest struct {
 	JobTitle       string `json:"job_title"`
 	CompanyName    string `json:"company_name"`
 	JobDescription string `json:"job_description,omitempty"`
+	// JobID writes the letter for a saved job; its title, company and
+	// description fill in the fields above that are left empty
+	JobID string `json:"job_id,omitempty"`
 }
 
 // GenerateCoverLetterResponse represents the response from cover letter generation
@@ -80,5 +113,34 @@ type CoverLetterData struct {
 	JobTitle    string  `json:"job_title"`
 	CompanyName string  `json:"company_name"`
 	CVID        *string `json:"cv_id,omitempty"`
+	JobID       *string `json:"job_id,omitempty"`
 	CreatedAt   string  `json:"created_at"`
 }
+
+// Stream event types sent while a generation is streamed to the client
+const (
+	EventAnalysisDone     = "analysis_done"
+	EventRequirementsDone = "requirements_done"
+	EventTailoringStarted = "tailoring_started"
+	EventVerificationDone = "verification_done"
+	EventWritingStarted   = "writing_started"
+	EventChunk            = "chunk"
+	EventRetry            = "retry"
+	EventComplete         = "complete"
+	EventError            = "error"
+)
+
+// StreamEvent represents a progress event emitted during a streamed generation
+type StreamEvent struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// ChunkData is the payload of an EventChunk event
+type ChunkData struct {
+	Text string `json:"text"`
+}
+
+// EmitFunc receives progress events during a streamed generation.
+// Returning an error (e.g. the client disconnected) aborts the generation.
+type EmitFunc func(event StreamEvent) error
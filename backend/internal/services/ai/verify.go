@@ -0,0 +1,324 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/terms"
+)
+
+// Verification modes for tailored CVs
+const (
+	// VerifyModeStrip removes fabricated entries and restores changed facts
+	// from the master profile
+	VerifyModeStrip = "strip"
+	// VerifyModeFlag leaves the tailored CV as generated and only reports findings
+	VerifyModeFlag = "flag"
+)
+
+// verifyTailoredCV checks a tailored CV against the master profile for facts
+// the profile does not back: employers, degrees and certificates it does not
+// list, dates that differ from it, and skills it never mentions. In strip
+// mode the tailored CV is corrected in place; in flag mode it is left as is.
+// Summaries and highlights are free text and are not checked.
+func verifyTailoredCV(profile, tailored *models.JSONResume, mode string) *models.Verification {
+	v := &verifier{
+		strip:    mode == VerifyModeStrip,
+		findings: []models.Finding{},
+	}
+
+	tailored.Work = v.verifyWork(profile.Work, tailored.Work)
+	tailored.Education = v.verifyEducation(profile.Education, tailored.Education)
+	tailored.Certificates = v.verifyCertificates(profile.Certificates, tailored.Certificates)
+	tailored.Skills = v.verifySkills(profileCorpus(profile), tailored.Skills)
+
+	return &models.Verification{Mode: mode, Findings: v.findings}
+}
+
+// verifier accumulates findings while checking a tailored CV
+type verifier struct {
+	strip    bool
+	findings []models.Finding
+}
+
+// report records a finding; fixAction is what the caller does about it in
+// strip mode. A removed entry is not in the stored CV, so its path is where it
+// was in the CV as generated, as the message says.
+func (v *verifier) report(kind, path, value, source, message, fixAction string) {
+	action := models.FindingFlagged
+	if v.strip {
+		action = fixAction
+		if action == models.FindingRemoved {
+			message = fmt.Sprintf("%s; removed from %s of the generated CV", message, path)
+		}
+	}
+	v.findings = append(v.findings, models.Finding{
+		Kind:    kind,
+		Path:    path,
+		Value:   value,
+		Source:  source,
+		Action:  action,
+		Message: message,
+	})
+}
+
+func (v *verifier) verifyWork(source, tailored []models.Work) []models.Work {
+	used := make([]bool, len(source))
+	kept := make([]models.Work, 0, len(tailored))
+	for i, work := range tailored {
+		match := -1
+		for j, src := range source {
+			if used[j] || normalize(src.Name) != normalize(work.Name) {
+				continue
+			}
+			// Prefer the same position when the employer appears more than once
+			if match < 0 || normalize(src.Position) == normalize(work.Position) {
+				match = j
+			}
+		}
+
+		if match < 0 {
+			if work.Name == "" {
+				kept = append(kept, work)
+				continue
+			}
+			v.report(models.FindingNewEmployer, fmt.Sprintf("work[%d]", i), work.Name, "",
+				fmt.Sprintf("Employer %q is not in your profile", work.Name), models.FindingRemoved)
+			if !v.strip {
+				kept = append(kept, work)
+			}
+			continue
+		}
+		used[match] = true
+
+		// Restored entries are located in the CV that is kept
+		path := fmt.Sprintf("work[%d]", len(kept))
+		src := source[match]
+		if datesDiffer(work.StartDate, work.EndDate, src.StartDate, src.EndDate) {
+			v.report(models.FindingChangedDates, path, dateRange(work.StartDate, work.EndDate), dateRange(src.StartDate, src.EndDate),
+				fmt.Sprintf("Dates at %s differ from your profile", work.Name), models.FindingRestored)
+			if v.strip {
+				work.StartDate, work.EndDate = src.StartDate, src.EndDate
+			}
+		}
+		kept = append(kept, work)
+	}
+	return kept
+}
+
+func (v *verifier) verifyEducation(source, tailored []models.Education) []models.Education {
+	used := make([]bool, len(source))
+	kept := make([]models.Education, 0, len(tailored))
+	for i, edu := range tailored {
+		match := -1
+		for j, src := range source {
+			if used[j] || normalize(src.Institution) != normalize(edu.Institution) {
+				continue
+			}
+			if match < 0 || sameDegree(src, edu) {
+				match = j
+			}
+		}
+
+		if match < 0 {
+			v.report(models.FindingNewDegree, fmt.Sprintf("education[%d]", i), degreeLabel(edu), "",
+				fmt.Sprintf("Degree from %q is not in your profile", edu.Institution), models.FindingRemoved)
+			if !v.strip {
+				kept = append(kept, edu)
+			}
+			continue
+		}
+		used[match] = true
+
+		path := fmt.Sprintf("education[%d]", len(kept))
+		src := source[match]
+		if !sameDegree(src, edu) {
+			v.report(models.FindingChangedDegree, path, degreeLabel(edu), degreeLabel(src),
+				fmt.Sprintf("Degree at %s differs from your profile", src.Institution), models.FindingRestored)
+			if v.strip {
+				edu.StudyType, edu.Area = src.StudyType, src.Area
+			}
+		}
+		if datesDiffer(edu.StartDate, edu.EndDate, src.StartDate, src.EndDate) {
+			v.report(models.FindingChangedDates, path, dateRange(edu.StartDate, edu.EndDate), dateRange(src.StartDate, src.EndDate),
+				fmt.Sprintf("Dates at %s differ from your profile", src.Institution), models.FindingRestored)
+			if v.strip {
+				edu.StartDate, edu.EndDate = src.StartDate, src.EndDate
+			}
+		}
+		kept = append(kept, edu)
+	}
+	return kept
+}
+
+func (v *verifier) verifyCertificates(source, tailored []models.Certificate) []models.Certificate {
+	kept := make([]models.Certificate, 0, len(tailored))
+	for i, cert := range tailored {
+		var src *models.Certificate
+		for j := range source {
+			if normalize(source[j].Name) == normalize(cert.Name) {
+				src = &source[j]
+				break
+			}
+		}
+
+		if src == nil {
+			v.report(models.FindingNewCertificate, fmt.Sprintf("certificates[%d]", i), cert.Name, "",
+				fmt.Sprintf("Certificate %q is not in your profile", cert.Name), models.FindingRemoved)
+			if !v.strip {
+				kept = append(kept, cert)
+			}
+			continue
+		}
+
+		if normalize(cert.Date) != normalize(src.Date) {
+			v.report(models.FindingChangedDates, fmt.Sprintf("certificates[%d]", len(kept)), cert.Date, src.Date,
+				fmt.Sprintf("Date of %s differs from your profile", src.Name), models.FindingRestored)
+			if v.strip {
+				cert.Date = src.Date
+			}
+		}
+		kept = append(kept, cert)
+	}
+	return kept
+}
+
+// verifySkills checks every skill keyword, or the skill name when it has no
+// keywords, against all text in the profile. Skill group names such as
+// "Backend" are labels, not claims, so they are not checked.
+func (v *verifier) verifySkills(corpus []*terms.Text, tailored []models.Skill) []models.Skill {
+	kept := make([]models.Skill, 0, len(tailored))
+	for i, skill := range tailored {
+		path := fmt.Sprintf("skills[%d]", i)
+
+		if len(skill.Keywords) == 0 {
+			if mentions(corpus, skill.Name) {
+				kept = append(kept, skill)
+				continue
+			}
+			v.report(models.FindingNewSkill, path+".name", skill.Name, "",
+				fmt.Sprintf("Skill %q is not mentioned in your profile", skill.Name), models.FindingRemoved)
+			if !v.strip {
+				kept = append(kept, skill)
+			}
+			continue
+		}
+
+		keywords := make([]string, 0, len(skill.Keywords))
+		for j, keyword := range skill.Keywords {
+			if mentions(corpus, keyword) {
+				keywords = append(keywords, keyword)
+				continue
+			}
+			v.report(models.FindingNewSkill, fmt.Sprintf("%s.keywords[%d]", path, j), keyword, "",
+				fmt.Sprintf("Skill %q is not mentioned in your profile", keyword), models.FindingRemoved)
+			if !v.strip {
+				keywords = append(keywords, keyword)
+			}
+		}
+
+		// A skill group left without keywords has nothing to show
+		if len(keywords) == 0 {
+			continue
+		}
+		skill.Keywords = keywords
+		kept = append(kept, skill)
+	}
+	return kept
+}
+
+// profileCorpus returns all text in a profile, prepared for matching skills
+func profileCorpus(profile *models.JSONResume) []*terms.Text {
+	var texts []string
+	for _, section := range profile.SectionsJSON() {
+		var value interface{}
+		if json.Unmarshal(section, &value) == nil {
+			texts = collectStrings(value, texts)
+		}
+	}
+
+	corpus := make([]*terms.Text, 0, len(texts))
+	for _, text := range texts {
+		corpus = append(corpus, terms.NewText(text))
+	}
+	return corpus
+}
+
+func collectStrings(value interface{}, texts []string) []string {
+	switch v := value.(type) {
+	case string:
+		texts = append(texts, v)
+	case []interface{}:
+		for _, element := range v {
+			texts = collectStrings(element, texts)
+		}
+	case map[string]interface{}:
+		for _, element := range v {
+			texts = collectStrings(element, texts)
+		}
+	}
+	return texts
+}
+
+// mentions reports whether any text of the corpus mentions a skill, the way
+// requirements are matched against the profile. A skill without letters or
+// digits has nothing to check.
+func mentions(corpus []*terms.Text, skill string) bool {
+	if normalize(skill) == "" {
+		return true
+	}
+	for _, text := range corpus {
+		if text.Mentions(skill) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lowercases text and collapses everything but letters, digits and
+// the characters of names like C++ and C# into single spaces
+func normalize(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+func datesDiffer(start, end, sourceStart, sourceEnd string) bool {
+	return normalize(start) != normalize(sourceStart) || normalize(end) != normalize(sourceEnd)
+}
+
+func dateRange(start, end string) string {
+	if start == "" && end == "" {
+		return ""
+	}
+	if end == "" {
+		end = "present"
+	}
+	return start + " - " + end
+}
+
+func sameDegree(a, b models.Education) bool {
+	return normalize(a.StudyType) == normalize(b.StudyType) && normalize(a.Area) == normalize(b.Area)
+}
+
+func degreeLabel(edu models.Education) string {
+	label := strings.TrimSpace(edu.StudyType + " " + edu.Area)
+	if edu.Institution != "" {
+		label = strings.TrimSpace(label + ", " + edu.Institution)
+	}
+	return strings.TrimPrefix(label, ", ")
+}
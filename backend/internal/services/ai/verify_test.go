@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cv-gen/backend/internal/models"
+)
+
+func verifyProfile() *models.JSONResume {
+	return &models.JSONResume{
+		Basics: &models.Basics{Name: "Ada Lovelace", Summary: "Backend engineer working in Go and C++"},
+		Work: []models.Work{
+			{Name: "Acme Corp", Position: "Engineer", StartDate: "2019-01", EndDate: "2022-06",
+				Highlights: []string{"Ran PostgreSQL on Kubernetes"}},
+		},
+		Education: []models.Education{
+			{Institution: "TU Berlin", StudyType: "BSc", Area: "Computer Science", StartDate: "2015", EndDate: "2018"},
+		},
+		Certificates: []models.Certificate{{Name: "CKA", Date: "2021-03"}},
+		Skills:       []models.Skill{{Name: "Backend", Keywords: []string{"Go", "PostgreSQL"}}},
+	}
+}
+
+func TestVerifyTailoredCV(t *testing.T) {
+	tailored := func() *models.JSONResume {
+		return &models.JSONResume{
+			Work: []models.Work{
+				{Name: "ACME Corp.", Position: "Engineer", StartDate: "2018-01", EndDate: "2022-06"},
+				{Name: "Globex", Position: "Staff Engineer"},
+			},
+			Education: []models.Education{
+				{Institution: "TU Berlin", StudyType: "MSc", Area: "Computer Science", StartDate: "2015", EndDate: "2018"},
+				{Institution: "MIT", StudyType: "PhD", Area: "Physics"},
+			},
+			Certificates: []models.Certificate{{Name: "CKA", Date: "2021-03"}, {Name: "AWS Solutions Architect"}},
+			Skills: []models.Skill{
+				{Name: "Languages", Keywords: []string{"Go", "C++", "Rust"}},
+				{Name: "Platform", Keywords: []string{"Terraform"}},
+				{Name: "Kubernetes"},
+			},
+		}
+	}
+
+	wantKinds := []string{
+		models.FindingChangedDates,
+		models.FindingNewEmployer,
+		models.FindingChangedDegree,
+		models.FindingNewDegree,
+		models.FindingNewCertificate,
+		models.FindingNewSkill,
+		models.FindingNewSkill,
+	}
+
+	t.Run("strip", func(t *testing.T) {
+		cv := tailored()
+		verification := verifyTailoredCV(verifyProfile(), cv, VerifyModeStrip)
+
+		var kinds []string
+		for _, f := range verification.Findings {
+			kinds = append(kinds, f.Kind)
+			if f.Action == models.FindingFlagged {
+				t.Errorf("expected %s at %s to be fixed in strip mode", f.Kind, f.Path)
+			}
+		}
+		if !reflect.DeepEqual(kinds, wantKinds) {
+			t.Fatalf("unexpected findings %+v", verification.Findings)
+		}
+		if dates := verification.Findings[0]; dates.Path != "work[0]" || dates.Source != "2019-01 - 2022-06" {
+			t.Errorf("unexpected date finding %+v", dates)
+		}
+
+		if len(cv.Work) != 1 || cv.Work[0].StartDate != "2019-01" {
+			t.Errorf("expected only Acme with restored dates, got %+v", cv.Work)
+		}
+		if len(cv.Education) != 1 || cv.Education[0].StudyType != "BSc" {
+			t.Errorf("expected only the restored BSc, got %+v", cv.Education)
+		}
+		if len(cv.Certificates) != 1 || cv.Certificates[0].Name != "CKA" {
+			t.Errorf("expected only CKA, got %+v", cv.Certificates)
+		}
+		wantSkills := []models.Skill{
+			{Name: "Languages", Keywords: []string{"Go", "C++"}},
+			{Name: "Kubernetes"},
+		}
+		if !reflect.DeepEqual(cv.Skills, wantSkills) {
+			t.Errorf("unexpected skills %+v", cv.Skills)
+		}
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		cv := tailored()
+		verification := verifyTailoredCV(verifyProfile(), cv, VerifyModeFlag)
+
+		if len(verification.Findings) != len(wantKinds) {
+			t.Fatalf("unexpected findings %+v", verification.Findings)
+		}
+		for _, f := range verification.Findings {
+			if f.Action != models.FindingFlagged {
+				t.Errorf("expected %s at %s to only be flagged, got %s", f.Kind, f.Path, f.Action)
+			}
+		}
+		if !reflect.DeepEqual(cv, tailored()) {
+			t.Errorf("expected the CV to be left as generated, got %+v", cv)
+		}
+	})
+}
+
+func TestVerifyTailoredCVConsistent(t *testing.T) {
+	profile := verifyProfile()
+	cv := verifyProfile()
+	cv.Basics.Summary = "Go engineer who enjoys databases"
+	cv.Work[0].Highlights = []string{"Operated a PostgreSQL fleet"}
+
+	verification := verifyTailoredCV(profile, cv, VerifyModeStrip)
+	if len(verification.Findings) != 0 {
+		t.Errorf("expected no findings, got %+v", verification.Findings)
+	}
+	if !reflect.DeepEqual(cv.Skills, profile.Skills) {
+		t.Errorf("expected skills to be kept, got %+v", cv.Skills)
+	}
+}
+
+func TestVerifyTailoredCVPathsAfterRemoval(t *testing.T) {
+	cv := &models.JSONResume{
+		Work: []models.Work{
+			{Name: "Globex", Position: "Staff Engineer"},
+			{Name: "Acme Corp", Position: "Engineer", StartDate: "2018-01", EndDate: "2022-06"},
+		},
+		Certificates: []models.Certificate{{Name: "AWS Solutions Architect"}, {Name: "CKA", Date: "2020-01"}},
+	}
+
+	verification := verifyTailoredCV(verifyProfile(), cv, VerifyModeStrip)
+
+	want := []struct{ kind, path, action string }{
+		{models.FindingNewEmployer, "work[0]", models.FindingRemoved},
+		{models.FindingChangedDates, "work[0]", models.FindingRestored},
+		{models.FindingNewCertificate, "certificates[0]", models.FindingRemoved},
+		{models.FindingChangedDates, "certificates[0]", models.FindingRestored},
+	}
+	if len(verification.Findings) != len(want) {
+		t.Fatalf("unexpected findings %+v", verification.Findings)
+	}
+	for i, w := range want {
+		f := verification.Findings[i]
+		if f.Kind != w.kind || f.Path != w.path || f.Action != w.action {
+			t.Errorf("finding %d = %+v, want %s at %s %s", i, f, w.kind, w.path, w.action)
+		}
+	}
+	if msg := verification.Findings[0].Message; !strings.Contains(msg, "work[0] of the generated CV") {
+		t.Errorf("expected the removal message to refer to the generated CV, got %q", msg)
+	}
+
+	if cv.Work[0].Name != "Acme Corp" || cv.Work[0].StartDate != "2019-01" {
+		t.Errorf("expected Acme with restored dates at work[0], got %+v", cv.Work)
+	}
+	if cv.Certificates[0].Name != "CKA" || cv.Certificates[0].Date != "2021-03" {
+		t.Errorf("expected CKA with its restored date at certificates[0], got %+v", cv.Certificates)
+	}
+}
@@ -0,0 +1,434 @@
+// Package applications tracks job applications made with generated CVs and
+// cover letters through the hiring pipeline
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+)
+
+var (
+	// ErrNotFound is returned when an application is not found
+	ErrNotFound = errors.New("application not found")
+	// ErrInvalidData is returned when invalid data is provided
+	ErrInvalidData = errors.New("invalid application data")
+	// ErrInvalidStatus is returned for a status that is not an application status
+	ErrInvalidStatus = errors.New("invalid application status")
+	// ErrInvalidTransition is returned when an application cannot move to the requested status
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+// Service provides application tracking operations
+type Service struct {
+	db      db.TxBeginner
+	queries *db.Queries
+}
+
+// New creates a new application service
+func New(pool db.TxBeginner, queries *db.Queries) *Service {
+	return &Service{
+		db:      pool,
+		queries: queries,
+	}
+}
+
+// ApplicationResponse represents the API response for application operations
+type ApplicationResponse struct {
+	ID            string  `json:"id"`
+	CVID          *string `json:"cv_id,omitempty"`
+	CoverLetterID *string `json:"cover_letter_id,omitempty"`
+	JobID         *string `json:"job_id,omitempty"`
+	JobTitle      string  `json:"job_title,omitempty"`
+	CompanyName   string  `json:"company_name,omitempty"`
+	JobURL        string  `json:"job_url,omitempty"`
+	Status        string  `json:"status"`
+	// NextStatuses lists the statuses the application can move to
+	NextStatuses []string `json:"next_statuses"`
+	Notes        string   `json:"notes"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	// History is only included when a single application is requested
+	History []StatusChangeResponse `json:"history,omitempty"`
+}
+
+// StatusChangeResponse represents one status transition of an application
+type StatusChangeResponse struct {
+	// FromStatus is empty for the status the application was created with
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+// CreateApplicationInput represents input for creating an application
+type CreateApplicationInput struct {
+	CVID          string `json:"cv_id"`
+	CoverLetterID string `json:"cover_letter_id,omitempty"`
+	// Status is drafted or applied; defaults to drafted
+	Status string `json:"status,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// UpdateApplicationInput represents input for updating an application; nil fields are left unchanged
+type UpdateApplicationInput struct {
+	CoverLetterID *string `json:"cover_letter_id,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+}
+
+// ChangeStatusInput represents input for moving an application to another status
+type ChangeStatusInput struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
+
+// ListApplications returns the user's applications, most recently updated
+// first, optionally only those in the given status
+func (s *Service) ListApplications(ctx context.Context, userID, status string) ([]ApplicationResponse, error) {
+	if status != "" && !IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+
+	applications, err := s.queries.ListApplicationsByUser(ctx, db.ListApplicationsByUserParams{
+		UserID: userID,
+		Status: pgtype.Text{String: status, Valid: status != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	items := make([]ApplicationResponse, 0, len(applications))
+	for _, application := range applications {
+		items = append(items, *applicationToResponse(application))
+	}
+	return items, nil
+}
+
+// GetApplication retrieves a specific application with its status history
+func (s *Service) GetApplication(ctx context.Context, userID, applicationID string) (*ApplicationResponse, error) {
+	uuid, err := parseUUID(applicationID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	application, err := s.queries.GetApplicationByUserAndId(ctx, db.GetApplicationByUserAndIdParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return withHistory(ctx, s.queries, application)
+}
+
+// CreateApplication records an application made with one of the user's CVs.
+// The job title, company and URL are copied from the CV so the record
+// survives the CV being deleted.
+func (s *Service) CreateApplication(ctx context.Context, userID string, input CreateApplicationInput) (*ApplicationResponse, error) {
+	status := input.Status
+	if status == "" {
+		status = StatusDrafted
+	}
+	if status != StatusDrafted && status != StatusApplied {
+		return nil, fmt.Errorf("%w: an application starts as %s or %s", ErrInvalidData, StatusDrafted, StatusApplied)
+	}
+
+	cvID, err := parseUUID(input.CVID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cv_id is required", ErrInvalidData)
+	}
+	cv, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: cvID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: cv not found", ErrInvalidData)
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	var coverLetterID pgtype.UUID
+	if input.CoverLetterID != "" {
+		if coverLetterID, err = s.coverLetterID(ctx, userID, input.CoverLetterID); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	application, err := q.CreateApplication(ctx, db.CreateApplicationParams{
+		UserID:        userID,
+		CvID:          cv.ID,
+		CoverLetterID: coverLetterID,
+		JobID:         cv.JobID,
+		JobTitle:      cv.JobTitle,
+		CompanyName:   cv.CompanyName,
+		JobUrl:        cv.JobUrl,
+		Status:        status,
+		Notes:         input.Notes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+
+	// The initial status is the first entry of the history
+	_, err = q.CreateApplicationStatusChange(ctx, db.CreateApplicationStatusChangeParams{
+		ApplicationID: application.ID,
+		ToStatus:      status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	resp, err := withHistory(ctx, q, application)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit application: %w", err)
+	}
+
+	return resp, nil
+}
+
+// UpdateApplication updates the notes or cover letter of an application
+func (s *Service) UpdateApplication(ctx context.Context, userID, applicationID string, input UpdateApplicationInput) (*ApplicationResponse, error) {
+	uuid, err := parseUUID(applicationID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	// Fields left as zero value (Valid: false) preserve existing values via COALESCE
+	params := db.UpdateApplicationParams{
+		ID:     uuid,
+		UserID: userID,
+	}
+	if input.CoverLetterID != nil {
+		if params.CoverLetterID, err = s.coverLetterID(ctx, userID, *input.CoverLetterID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Notes != nil {
+		params.Notes = pgtype.Text{String: *input.Notes, Valid: true}
+	}
+
+	application, err := s.queries.UpdateApplication(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update application: %w", err)
+	}
+
+	return withHistory(ctx, s.queries, application)
+}
+
+// ChangeStatus moves an application to another status and records the
+// transition in its history. The application is locked while the transition
+// is checked, so concurrent changes cannot skip a step of the pipeline.
+func (s *Service) ChangeStatus(ctx context.Context, userID, applicationID string, input ChangeStatusInput) (*ApplicationResponse, error) {
+	uuid, err := parseUUID(applicationID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if !IsValidStatus(input.Status) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, input.Status)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	current, err := q.GetApplicationForUpdate(ctx, db.GetApplicationForUpdateParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock application: %w", err)
+	}
+
+	if !CanTransition(current.Status, input.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, current.Status, input.Status)
+	}
+
+	application, err := q.UpdateApplicationStatus(ctx, db.UpdateApplicationStatusParams{
+		ID:     uuid,
+		UserID: userID,
+		Status: input.Status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update application status: %w", err)
+	}
+
+	_, err = q.CreateApplicationStatusChange(ctx, db.CreateApplicationStatusChangeParams{
+		ApplicationID: application.ID,
+		FromStatus:    pgtype.Text{String: current.Status, Valid: true},
+		ToStatus:      input.Status,
+		Note:          strings.TrimSpace(input.Note),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	resp, err := withHistory(ctx, q, application)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit status change: %w", err)
+	}
+
+	return resp, nil
+}
+
+// DeleteApplication deletes an application and its history
+func (s *Service) DeleteApplication(ctx context.Context, userID, applicationID string) error {
+	uuid, err := parseUUID(applicationID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	// First check if application exists and belongs to user
+	_, err = s.queries.GetApplicationByUserAndId(ctx, db.GetApplicationByUserAndIdParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	err = s.queries.DeleteApplication(ctx, db.DeleteApplicationParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+
+	return nil
+}
+
+// coverLetterID parses the ID of one of the user's cover letters
+func (s *Service) coverLetterID(ctx context.Context, userID, coverLetterID string) (pgtype.UUID, error) {
+	uuid, err := parseUUID(coverLetterID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("%w: cover letter not found", ErrInvalidData)
+	}
+
+	_, err = s.queries.GetCoverLetterByUserAndId(ctx, db.GetCoverLetterByUserAndIdParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, fmt.Errorf("%w: cover letter not found", ErrInvalidData)
+		}
+		return pgtype.UUID{}, fmt.Errorf("failed to get cover letter: %w", err)
+	}
+
+	return uuid, nil
+}
+
+// withHistory returns the response for an application including its status history
+func withHistory(ctx context.Context, q *db.Queries, application db.Application) (*ApplicationResponse, error) {
+	changes, err := q.ListApplicationStatusChanges(ctx, application.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status changes: %w", err)
+	}
+
+	resp := applicationToResponse(application)
+	resp.History = make([]StatusChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		resp.History = append(resp.History, StatusChangeResponse{
+			FromStatus: textToString(change.FromStatus),
+			ToStatus:   change.ToStatus,
+			Note:       change.Note,
+			ChangedAt:  timestampToString(change.ChangedAt),
+		})
+	}
+	return resp, nil
+}
+
+// Helper functions
+
+func applicationToResponse(application db.Application) *ApplicationResponse {
+	resp := &ApplicationResponse{
+		ID:           uuidToString(application.ID),
+		JobTitle:     textToString(application.JobTitle),
+		CompanyName:  textToString(application.CompanyName),
+		JobURL:       textToString(application.JobUrl),
+		Status:       application.Status,
+		NextStatuses: NextStatuses(application.Status),
+		Notes:        application.Notes,
+		CreatedAt:    timestampToString(application.CreatedAt),
+		UpdatedAt:    timestampToString(application.UpdatedAt),
+	}
+	if application.CvID.Valid {
+		cvID := uuidToString(application.CvID)
+		resp.CVID = &cvID
+	}
+	if application.CoverLetterID.Valid {
+		coverLetterID := uuidToString(application.CoverLetterID)
+		resp.CoverLetterID = &coverLetterID
+	}
+	if application.JobID.Valid {
+		jobID := uuidToString(application.JobID)
+		resp.JobID = &jobID
+	}
+	return resp
+}
+
+func uuidToString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	b := id.Bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func parseUUID(s string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	err := uuid.Scan(s)
+	return uuid, err
+}
+
+func textToString(t pgtype.Text) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.String
+}
+
+func timestampToString(ts pgtype.Timestamptz) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format(time.RFC3339)
+}
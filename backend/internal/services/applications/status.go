@@ -0,0 +1,45 @@
+package applications
+
+// Application statuses. An application moves forward through the pipeline
+// from drafted to an offer; rejected and withdrawn end it.
+const (
+	StatusDrafted   = "drafted"
+	StatusApplied   = "applied"
+	StatusScreening = "screening"
+	StatusInterview = "interview"
+	StatusOffer     = "offer"
+	StatusRejected  = "rejected"
+	StatusWithdrawn = "withdrawn"
+)
+
+// transitions lists the statuses each status can move to
+var transitions = map[string][]string{
+	StatusDrafted:   {StatusApplied, StatusWithdrawn},
+	StatusApplied:   {StatusScreening, StatusInterview, StatusRejected, StatusWithdrawn},
+	StatusScreening: {StatusInterview, StatusRejected, StatusWithdrawn},
+	StatusInterview: {StatusOffer, StatusRejected, StatusWithdrawn},
+	StatusOffer:     {StatusWithdrawn},
+	StatusRejected:  {},
+	StatusWithdrawn: {},
+}
+
+// IsValidStatus reports whether status is a known application status
+func IsValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// CanTransition reports whether an application may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses returns the statuses an application in the given status can move to
+func NextStatuses(status string) []string {
+	return append([]string{}, transitions[status]...)
+}
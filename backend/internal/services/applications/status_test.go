@@ -0,0 +1,55 @@
+package applications
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusDrafted, StatusApplied, true},
+		{StatusDrafted, StatusWithdrawn, true},
+		{StatusDrafted, StatusInterview, false},
+		{StatusApplied, StatusScreening, true},
+		{StatusApplied, StatusInterview, true},
+		{StatusApplied, StatusOffer, false},
+		{StatusApplied, StatusDrafted, false},
+		{StatusScreening, StatusInterview, true},
+		{StatusScreening, StatusRejected, true},
+		{StatusInterview, StatusOffer, true},
+		{StatusInterview, StatusScreening, false},
+		{StatusOffer, StatusWithdrawn, true},
+		{StatusOffer, StatusRejected, false},
+		{StatusRejected, StatusApplied, false},
+		{StatusWithdrawn, StatusApplied, false},
+		{StatusApplied, StatusApplied, false},
+		{"unknown", StatusApplied, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEveryTransitionTargetIsAStatus(t *testing.T) {
+	for from, targets := range transitions {
+		for _, to := range targets {
+			if !IsValidStatus(to) {
+				t.Errorf("%s moves to unknown status %q", from, to)
+			}
+		}
+	}
+}
+
+func TestNextStatusesIsACopy(t *testing.T) {
+	next := NextStatuses(StatusDrafted)
+	next[0] = StatusOffer
+
+	if CanTransition(StatusDrafted, StatusOffer) {
+		t.Error("changing the result of NextStatuses changed the transitions")
+	}
+}
@@ -39,6 +39,7 @@ type CoverLetterResponse struct {
 	ID          string  `json:"id"`
 	UserID      string  `json:"user_id"`
 	CVID        *string `json:"cv_id,omitempty"`
+	JobID       *string `json:"job_id,omitempty"`
 	Content     string  `json:"content"`
 	JobTitle    string  `json:"job_title,omitempty"`
 	CompanyName string  `json:"company_name,omitempty"`
@@ -51,6 +52,7 @@ type CoverLetterListItem struct {
 	ID          string  `json:"id"`
 	UserID      string  `json:"user_id"`
 	CVID        *string `json:"cv_id,omitempty"`
+	JobID       *string `json:"job_id,omitempty"`
 	JobTitle    string  `json:"job_title,omitempty"`
 	CompanyName string  `json:"company_name,omitempty"`
 	CreatedAt   string  `json:"created_at"`
@@ -91,6 +93,10 @@ func (s *Service) ListCoverLetters(ctx context.Context, userID string) ([]CoverL
 			cvID := uuidToString(cl.CvID)
 			item.CVID = &cvID
 		}
+		if cl.JobID.Valid {
+			jobID := uuidToString(cl.JobID)
+			item.JobID = &jobID
+		}
 		items = append(items, item)
 	}
 
@@ -211,6 +217,10 @@ func coverLetterToResponse(cl db.CoverLetter) *CoverLetterResponse {
 		cvID := uuidToString(cl.CvID)
 		resp.CVID = &cvID
 	}
+	if cl.JobID.Valid {
+		jobID := uuidToString(cl.JobID)
+		resp.JobID = &jobID
+	}
 	return resp
 }
 
@@ -0,0 +1,387 @@
+// Package credits provides atomic reservation and refund of generation credits
+package credits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+)
+
+var (
+	// ErrOutOfCredits is returned when the user has no remaining credits
+	ErrOutOfCredits = errors.New("out of generation credits")
+	// ErrInsufficientPaidCredits is returned when revoking more paid credits than the user has
+	ErrInsufficientPaidCredits = errors.New("insufficient paid credits")
+)
+
+// Source identifies which credit pool a reservation was taken from
+type Source string
+
+const (
+	// SourceFree means the reservation consumed a free generation
+	SourceFree Source = "free"
+	// SourcePaid means the reservation consumed a paid credit
+	SourcePaid Source = "paid"
+)
+
+// Reasons recorded on credit transactions
+const (
+	ReasonCVGeneration = "cv_generation"
+	ReasonCoverLetter  = "cover_letter"
+	ReasonPurchase     = "purchase"
+	ReasonAdminGrant   = "admin_grant"
+	ReasonAdminRevoke  = "admin_revoke"
+	ReasonAdminReset   = "admin_reset"
+	ReasonRefund       = "refund"
+)
+
+// Service reserves, commits and releases generation credits
+type Service struct {
+	db      db.TxBeginner
+	queries *db.Queries
+}
+
+// New creates a new credits service
+func New(pool db.TxBeginner, queries *db.Queries) *Service {
+	return &Service{
+		db:      pool,
+		queries: queries,
+	}
+}
+
+// Reservation is a credit held for a single generation. It must be either
+// committed once the generation is saved or released if the generation fails.
+type Reservation struct {
+	UserID string
+	Source Source
+	// Balance is the user's credit row after the reservation was taken
+	Balance db.UserCredit
+	// TransactionID is the ledger entry that recorded the debit
+	TransactionID pgtype.UUID
+	// CVID and CoverLetterID are set by the caller once the generation is
+	// saved so Commit can link the debit to it
+	CVID          pgtype.UUID
+	CoverLetterID pgtype.UUID
+}
+
+// Reserve atomically takes one credit (free first, then paid) for the user.
+// The credit row is locked for the duration of the check and update, so
+// concurrent generations cannot overdraw the balance. The debit is recorded in
+// the credit ledger under the given reason in the same transaction.
+func (s *Service) Reserve(ctx context.Context, userID, reason string) (*Reservation, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	// Make sure the row exists before locking it
+	if _, err := q.GetOrCreateUserCredits(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get credits: %w", err)
+	}
+
+	current, err := q.GetUserCreditsForUpdate(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock credits: %w", err)
+	}
+
+	if Remaining(current) <= 0 {
+		return nil, ErrOutOfCredits
+	}
+
+	source := SourcePaid
+	if current.FreeGenerationsUsed < current.FreeGenerationsLimit {
+		source = SourceFree
+	}
+
+	// IncrementCreditsUsed applies the same free-first rule decided above
+	balance, err := q.IncrementCreditsUsed(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve credit: %w", err)
+	}
+
+	entry, err := q.CreateCreditTransaction(ctx, db.CreateCreditTransactionParams{
+		UserID:       userID,
+		Amount:       -1,
+		Reason:       reason,
+		BalanceAfter: Remaining(balance),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record credit transaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit credit reservation: %w", err)
+	}
+
+	return &Reservation{
+		UserID:        userID,
+		Source:        source,
+		Balance:       balance,
+		TransactionID: entry.ID,
+	}, nil
+}
+
+// Commit finalizes a reservation after the generation has been saved
+func (s *Service) Commit(ctx context.Context, reservation *Reservation) error {
+	// The credit was already debited when it was reserved; only link the
+	// ledger entry to what it paid for
+	if !reservation.CVID.Valid && !reservation.CoverLetterID.Valid {
+		return nil
+	}
+
+	err := s.queries.SetCreditTransactionReference(ctx, db.SetCreditTransactionReferenceParams{
+		ID:            reservation.TransactionID,
+		CvID:          reservation.CVID,
+		CoverLetterID: reservation.CoverLetterID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link credit transaction: %w", err)
+	}
+	return nil
+}
+
+// Release refunds a reservation after a failed generation. The refund entry
+// references the debit it returns.
+func (s *Service) Release(ctx context.Context, reservation *Reservation) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	balance, err := q.RefundCredit(ctx, db.RefundCreditParams{
+		RefundFree: reservation.Source == SourceFree,
+		UserID:     reservation.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refund credit: %w", err)
+	}
+
+	_, err = q.CreateCreditTransaction(ctx, db.CreateCreditTransactionParams{
+		UserID:                reservation.UserID,
+		Amount:                1,
+		Reason:                ReasonRefund,
+		BalanceAfter:          Remaining(balance),
+		RefundedTransactionID: reservation.TransactionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record credit transaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit credit refund: %w", err)
+	}
+
+	reservation.Balance = balance
+	return nil
+}
+
+// Grant adds paid credits to a user (purchases, admin grants) and records the
+// change in the credit ledger. A negative amount revokes credits.
+func (s *Service) Grant(ctx context.Context, userID string, amount int32, reason string) (db.UserCredit, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	balance, err := s.GrantTx(ctx, s.queries.WithTx(tx), userID, amount, reason)
+	if err != nil {
+		return db.UserCredit{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to commit credit grant: %w", err)
+	}
+	return balance, nil
+}
+
+// GrantTx is Grant within a transaction owned by the caller
+func (s *Service) GrantTx(ctx context.Context, q *db.Queries, userID string, amount int32, reason string) (db.UserCredit, error) {
+	// Make sure the row exists before locking it
+	if _, err := q.GetOrCreateUserCredits(ctx, userID); err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to get credits: %w", err)
+	}
+
+	current, err := q.GetUserCreditsForUpdate(ctx, userID)
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to lock credits: %w", err)
+	}
+
+	if current.PaidCredits+amount < 0 {
+		return db.UserCredit{}, ErrInsufficientPaidCredits
+	}
+
+	balance, err := q.AddPaidCredits(ctx, db.AddPaidCreditsParams{
+		UserID:      userID,
+		PaidCredits: amount,
+	})
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to add credits: %w", err)
+	}
+
+	_, err = q.CreateCreditTransaction(ctx, db.CreateCreditTransactionParams{
+		UserID:       userID,
+		Amount:       amount,
+		Reason:       reason,
+		BalanceAfter: Remaining(balance),
+	})
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to record credit transaction: %w", err)
+	}
+
+	return balance, nil
+}
+
+// ResetFreeUsage gives the user their full free allowance again and records
+// the restored generations in the credit ledger
+func (s *Service) ResetFreeUsage(ctx context.Context, userID, reason string) (db.UserCredit, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	// Make sure the row exists before locking it
+	if _, err := q.GetOrCreateUserCredits(ctx, userID); err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to get credits: %w", err)
+	}
+
+	current, err := q.GetUserCreditsForUpdate(ctx, userID)
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to lock credits: %w", err)
+	}
+
+	balance, err := q.ResetFreeGenerationsUsed(ctx, userID)
+	if err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to reset free usage: %w", err)
+	}
+
+	restored := Remaining(balance) - Remaining(current)
+	if restored > 0 {
+		_, err = q.CreateCreditTransaction(ctx, db.CreateCreditTransactionParams{
+			UserID:       userID,
+			Amount:       restored,
+			Reason:       reason,
+			BalanceAfter: Remaining(balance),
+		})
+		if err != nil {
+			return db.UserCredit{}, fmt.Errorf("failed to record credit transaction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.UserCredit{}, fmt.Errorf("failed to commit free usage reset: %w", err)
+	}
+	return balance, nil
+}
+
+// TransactionResponse is a single credit ledger entry
+type TransactionResponse struct {
+	ID            string `json:"id"`
+	Amount        int32  `json:"amount"`
+	Reason        string `json:"reason"`
+	CVID          string `json:"cv_id,omitempty"`
+	CoverLetterID string `json:"cover_letter_id,omitempty"`
+	BalanceAfter  int32  `json:"balance_after"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// HistoryResponse represents a paginated list of credit transactions
+type HistoryResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	Total        int64                 `json:"total"`
+	Page         int                   `json:"page"`
+	PageSize     int                   `json:"page_size"`
+	TotalPages   int                   `json:"total_pages"`
+}
+
+// History returns the user's credit transactions, newest first
+func (s *Service) History(ctx context.Context, userID string, page, pageSize int) (*HistoryResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := int32((page - 1) * pageSize)
+
+	// Get total count
+	total, err := s.queries.CountCreditTransactionsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count credit transactions: %w", err)
+	}
+
+	// Get paginated list
+	entries, err := s.queries.ListCreditTransactionsByUser(ctx, db.ListCreditTransactionsByUserParams{
+		UserID: userID,
+		Limit:  int32(pageSize),
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit transactions: %w", err)
+	}
+
+	items := make([]TransactionResponse, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, TransactionResponse{
+			ID:            uuidToString(entry.ID),
+			Amount:        entry.Amount,
+			Reason:        entry.Reason,
+			CVID:          uuidToString(entry.CvID),
+			CoverLetterID: uuidToString(entry.CoverLetterID),
+			BalanceAfter:  entry.BalanceAfter,
+			CreatedAt:     timestampToString(entry.CreatedAt),
+		})
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return &HistoryResponse{
+		Transactions: items,
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   totalPages,
+	}, nil
+}
+
+// Remaining calculates total remaining credits (free + paid)
+func Remaining(credits db.UserCredit) int32 {
+	freeRemaining := credits.FreeGenerationsLimit - credits.FreeGenerationsUsed
+	if freeRemaining < 0 {
+		freeRemaining = 0
+	}
+	return freeRemaining + credits.PaidCredits
+}
+
+func uuidToString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	b := id.Bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func timestampToString(ts pgtype.Timestamptz) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format(time.RFC3339)
+}
@@ -0,0 +1,63 @@
+package cv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/models"
+)
+
+// DiffResponse describes how a CV differs from the user's master profile
+type DiffResponse struct {
+	CVID             string             `json:"cv_id"`
+	ProfileUpdatedAt string             `json:"profile_updated_at,omitempty"`
+	Diff             *models.ResumeDiff `json:"diff"`
+}
+
+// DiffWithProfile compares a CV's content against the user's current master
+// profile, so users can review what tailoring changed. A user without a
+// profile sees every section of the CV as added.
+func (s *Service) DiffWithProfile(ctx context.Context, userID, cvID string) (*DiffResponse, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	cv, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: uuid, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	var cvData models.JSONResume
+	if len(cv.CvData) > 0 {
+		if err := json.Unmarshal(cv.CvData, &cvData); err != nil {
+			return nil, fmt.Errorf("failed to parse cv data: %w", err)
+		}
+	}
+
+	resp := &DiffResponse{CVID: uuidToString(cv.ID)}
+
+	var profileData *models.JSONResume
+	profile, err := s.queries.GetMasterProfile(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if err == nil {
+		profileData = &models.JSONResume{}
+		if err := json.Unmarshal(profile.ResumeData, profileData); err != nil {
+			return nil, fmt.Errorf("failed to parse profile data: %w", err)
+		}
+		resp.ProfileUpdatedAt = timestampToString(profile.UpdatedAt)
+	}
+
+	resp.Diff = models.DiffResumes(profileData, &cvData)
+	return resp, nil
+}
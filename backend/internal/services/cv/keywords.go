@@ -0,0 +1,63 @@
+package cv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/ats"
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/models"
+)
+
+// KeywordMatch returns the keyword report of a CV against its job
+// description. The report is worked out on request rather than on every read
+// of the CV, so its score always agrees with its breakdown.
+func (s *Service) KeywordMatch(ctx context.Context, userID, cvID string) (*ats.Result, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	cv, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: uuid, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	if textToString(cv.JobDescription) == "" {
+		return nil, ErrNoJobDescription
+	}
+	return keywordMatch(cv.JobDescription.String, cv.CvData)
+}
+
+// keywordScore scores CV content against a job description. The score is
+// not valid without a job description.
+func keywordScore(jobDescription pgtype.Text, cvData []byte) (pgtype.Int4, error) {
+	if textToString(jobDescription) == "" {
+		return pgtype.Int4{}, nil
+	}
+
+	result, err := keywordMatch(jobDescription.String, cvData)
+	if err != nil {
+		return pgtype.Int4{}, err
+	}
+	return pgtype.Int4{Int32: int32(result.Score), Valid: true}, nil
+}
+
+// keywordMatch matches stored CV content against a job description
+func keywordMatch(jobDescription string, cvData []byte) (*ats.Result, error) {
+	var resume models.JSONResume
+	if len(cvData) > 0 {
+		if err := json.Unmarshal(cvData, &resume); err != nil {
+			return nil, fmt.Errorf("failed to parse cv data: %w", err)
+		}
+	}
+	return ats.Score(jobDescription, &resume), nil
+}
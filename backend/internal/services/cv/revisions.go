@@ -0,0 +1,245 @@
+package cv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/models"
+)
+
+// Revision sources record who authored the content of a revision
+const (
+	SourceManualEdit   = "manual_edit"
+	SourceAIGeneration = "ai_generation"
+	SourceRestore      = "restore"
+)
+
+// ErrRevisionNotFound is returned when a CV revision is not found
+var ErrRevisionNotFound = errors.New("cv revision not found")
+
+// RevisionListItem represents a revision in list responses (without cv_data)
+type RevisionListItem struct {
+	Revision     int32  `json:"revision"`
+	Name         string `json:"name"`
+	TemplateID   string `json:"template_id"`
+	Source       string `json:"source"`
+	RestoredFrom *int32 `json:"restored_from,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// RevisionResponse represents a single revision with its content
+type RevisionResponse struct {
+	RevisionListItem
+	CVData *models.JSONResume `json:"cv_data"`
+}
+
+// RevisionsResponse lists the revisions of a CV, newest first. The first
+// item is the CV's current content.
+type RevisionsResponse struct {
+	Revisions []RevisionListItem `json:"revisions"`
+}
+
+// CreateWithRevision saves a new CV and records its content as the first
+// revision, in one transaction
+func (s *Service) CreateWithRevision(ctx context.Context, params db.CreateCVParams, source string) (db.GeneratedCv, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	cv, err := q.CreateCV(ctx, params)
+	if err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to create cv: %w", err)
+	}
+
+	if err := recordRevision(ctx, q, params.UserID, cv.ID, source, pgtype.Int4{}); err != nil {
+		return db.GeneratedCv{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to commit cv: %w", err)
+	}
+
+	return cv, nil
+}
+
+// updateWithRevision updates a CV and records the result as its next
+// revision, in one transaction. The CV is locked while update builds the
+// changes from its current row, so concurrent writes are numbered one after
+// the other and every one of them ends up in the history.
+func (s *Service) updateWithRevision(ctx context.Context, userID string, cvID pgtype.UUID, source string, restoredFrom pgtype.Int4, update func(current db.GeneratedCv) (db.UpdateCVParams, error)) (db.GeneratedCv, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	current, err := q.GetCVForUpdate(ctx, db.GetCVForUpdateParams{
+		ID:     cvID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.GeneratedCv{}, ErrNotFound
+		}
+		return db.GeneratedCv{}, fmt.Errorf("failed to lock cv: %w", err)
+	}
+
+	params, err := update(current)
+	if err != nil {
+		return db.GeneratedCv{}, err
+	}
+	params.ID, params.UserID = cvID, userID
+
+	cv, err := q.UpdateCV(ctx, params)
+	if err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to update cv: %w", err)
+	}
+
+	if err := recordRevision(ctx, q, userID, cv.ID, source, restoredFrom); err != nil {
+		return db.GeneratedCv{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.GeneratedCv{}, fmt.Errorf("failed to commit cv: %w", err)
+	}
+
+	return cv, nil
+}
+
+// recordRevision snapshots the current content of a CV as its next revision.
+// Every write to a CV's name, content or template records one in the same
+// transaction, so restoring never loses the content being replaced.
+func recordRevision(ctx context.Context, queries db.Querier, userID string, cvID pgtype.UUID, source string, restoredFrom pgtype.Int4) error {
+	_, err := queries.CreateCVRevision(ctx, db.CreateCVRevisionParams{
+		CvID:         cvID,
+		UserID:       userID,
+		Source:       source,
+		RestoredFrom: restoredFrom,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record cv revision: %w", err)
+	}
+	return nil
+}
+
+// ListRevisions returns the revision history of a CV
+func (s *Service) ListRevisions(ctx context.Context, userID, cvID string) (*RevisionsResponse, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if _, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: uuid, UserID: userID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	revisions, err := s.queries.ListCVRevisions(ctx, db.ListCVRevisionsParams{CvID: uuid, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cv revisions: %w", err)
+	}
+
+	items := make([]RevisionListItem, 0, len(revisions))
+	for _, revision := range revisions {
+		items = append(items, revisionToListItem(revision))
+	}
+
+	return &RevisionsResponse{Revisions: items}, nil
+}
+
+// GetRevision retrieves a single revision of a CV with its content
+func (s *Service) GetRevision(ctx context.Context, userID, cvID string, revision int32) (*RevisionResponse, error) {
+	rev, err := s.getRevision(ctx, userID, cvID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var cvData models.JSONResume
+	if len(rev.CvData) > 0 {
+		if err := json.Unmarshal(rev.CvData, &cvData); err != nil {
+			return nil, fmt.Errorf("failed to parse cv data: %w", err)
+		}
+	}
+
+	return &RevisionResponse{
+		RevisionListItem: revisionToListItem(rev),
+		CVData:           &cvData,
+	}, nil
+}
+
+// RestoreRevision makes a revision's name, content and template the CV's
+// current ones. The restore is itself recorded as a new revision, so it can
+// be undone by restoring the revision before it.
+func (s *Service) RestoreRevision(ctx context.Context, userID, cvID string, revision int32) (*CVResponse, error) {
+	rev, err := s.getRevision(ctx, userID, cvID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	cv, err := s.updateWithRevision(ctx, userID, rev.CvID, SourceRestore, pgtype.Int4{Int32: rev.Revision, Valid: true}, func(current db.GeneratedCv) (db.UpdateCVParams, error) {
+		score, err := keywordScore(current.JobDescription, rev.CvData)
+		if err != nil {
+			return db.UpdateCVParams{}, err
+		}
+		return db.UpdateCVParams{
+			Name:         pgtype.Text{String: rev.Name, Valid: true},
+			CvData:       rev.CvData,
+			TemplateID:   rev.TemplateID,
+			KeywordScore: score,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cvToResponse(cv)
+}
+
+func (s *Service) getRevision(ctx context.Context, userID, cvID string, revision int32) (db.CvRevision, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return db.CvRevision{}, ErrRevisionNotFound
+	}
+
+	rev, err := s.queries.GetCVRevision(ctx, db.GetCVRevisionParams{
+		CvID:     uuid,
+		UserID:   userID,
+		Revision: revision,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.CvRevision{}, ErrRevisionNotFound
+		}
+		return db.CvRevision{}, fmt.Errorf("failed to get cv revision: %w", err)
+	}
+	return rev, nil
+}
+
+func revisionToListItem(rev db.CvRevision) RevisionListItem {
+	item := RevisionListItem{
+		Revision:   rev.Revision,
+		Name:       rev.Name,
+		TemplateID: textToString(rev.TemplateID),
+		Source:     rev.Source,
+		CreatedAt:  timestampToString(rev.CreatedAt),
+	}
+	if rev.RestoredFrom.Valid {
+		restoredFrom := rev.RestoredFrom.Int32
+		item.RestoredFrom = &restoredFrom
+	}
+	return item
+}
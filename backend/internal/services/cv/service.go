@@ -13,6 +13,7 @@ import (
 
 	"cv-gen/backend/internal/db"
 	"cv-gen/backend/internal/models"
+	"cv-gen/backend/internal/themes"
 )
 
 var (
@@ -22,16 +23,22 @@ var (
 	ErrInvalidData = errors.New("invalid cv data")
 	// ErrUnauthorized is returned when user doesn't own the CV
 	ErrUnauthorized = errors.New("unauthorized access to cv")
+	// ErrInvalidTemplate is returned when a template ID is not a registered theme
+	ErrInvalidTemplate = errors.New("invalid template id")
+	// ErrNoJobDescription is returned when keywords are matched for a CV without a job description
+	ErrNoJobDescription = errors.New("cv has no job description")
 )
 
 // Service provides CV management operations
 type Service struct {
+	db      db.TxBeginner
 	queries *db.Queries
 }
 
 // New creates a new CV service
-func New(queries *db.Queries) *Service {
+func New(pool db.TxBeginner, queries *db.Queries) *Service {
 	return &Service{
+		db:      pool,
 		queries: queries,
 	}
 }
@@ -43,14 +50,19 @@ type CVResponse struct {
 	Name           string             `json:"name"`
 	CVData         *models.JSONResume `json:"cv_data"`
 	TemplateID     string             `json:"template_id"`
+	JobID          string             `json:"job_id,omitempty"`
 	JobURL         string             `json:"job_url,omitempty"`
 	JobTitle       string             `json:"job_title,omitempty"`
 	CompanyName    string             `json:"company_name,omitempty"`
 	JobDescription string             `json:"job_description,omitempty"`
 	MatchScore     *int               `json:"match_score,omitempty"`
-	AISuggestions  *AISuggestions     `json:"ai_suggestions,omitempty"`
-	CreatedAt      string             `json:"created_at"`
-	UpdatedAt      string             `json:"updated_at"`
+	// KeywordScore is the deterministic keyword score of the CV against its job description
+	KeywordScore  *int           `json:"keyword_score,omitempty"`
+	AISuggestions *AISuggestions `json:"ai_suggestions,omitempty"`
+	// JobRequirements is set on CVs generated with a parsed job description
+	JobRequirements *models.JobRequirements `json:"job_requirements,omitempty"`
+	CreatedAt       string                  `json:"created_at"`
+	UpdatedAt       string                  `json:"updated_at"`
 }
 
 // AISuggestions represents the AI analysis suggestions stored with a CV
@@ -61,19 +73,23 @@ type AISuggestions struct {
 	RelevantExperiences []string `json:"relevant_experiences"`
 	Suggestions         []string `json:"suggestions"`
 	KeywordsToInclude   []string `json:"keywords_to_include"`
+	// Verification is set on CVs generated after the hallucination guard was added
+	Verification *models.Verification `json:"verification,omitempty"`
 }
 
 // CVListItem represents a CV in list responses (without full cv_data)
 type CVListItem struct {
-	ID          string `json:"id"`
-	UserID      string `json:"user_id"`
-	Name        string `json:"name"`
-	TemplateID  string `json:"template_id"`
-	JobTitle    string `json:"job_title,omitempty"`
-	CompanyName string `json:"company_name,omitempty"`
-	MatchScore  *int   `json:"match_score,omitempty"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID           string `json:"id"`
+	UserID       string `json:"user_id"`
+	Name         string `json:"name"`
+	TemplateID   string `json:"template_id"`
+	JobID        string `json:"job_id,omitempty"`
+	JobTitle     string `json:"job_title,omitempty"`
+	CompanyName  string `json:"company_name,omitempty"`
+	MatchScore   *int   `json:"match_score,omitempty"`
+	KeywordScore *int   `json:"keyword_score,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
 }
 
 // ListResponse represents a paginated list response
@@ -132,6 +148,7 @@ func (s *Service) ListCVs(ctx context.Context, userID string, page, pageSize int
 			UserID:      cv.UserID,
 			Name:        cv.Name,
 			TemplateID:  textToString(cv.TemplateID),
+			JobID:       uuidToString(cv.JobID),
 			JobTitle:    textToString(cv.JobTitle),
 			CompanyName: textToString(cv.CompanyName),
 			CreatedAt:   timestampToString(cv.CreatedAt),
@@ -141,6 +158,10 @@ func (s *Service) ListCVs(ctx context.Context, userID string, page, pageSize int
 			score := int(cv.MatchScore.Int32)
 			item.MatchScore = &score
 		}
+		if cv.KeywordScore.Valid {
+			score := int(cv.KeywordScore.Int32)
+			item.KeywordScore = &score
+		}
 		items = append(items, item)
 	}
 
@@ -188,7 +209,10 @@ func (s *Service) CreateCV(ctx context.Context, userID string, input CreateCVInp
 	}
 	templateID := input.TemplateID
 	if templateID == "" {
-		templateID = "professional"
+		templateID = themes.DefaultID
+	}
+	if !themes.Valid(templateID) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTemplate, templateID)
 	}
 
 	// Get user's master profile to copy data from
@@ -204,7 +228,7 @@ func (s *Service) CreateCV(ctx context.Context, userID string, input CreateCVInp
 	}
 
 	// Create the CV
-	cv, err := s.queries.CreateCV(ctx, db.CreateCVParams{
+	cv, err := s.CreateWithRevision(ctx, db.CreateCVParams{
 		UserID:         userID,
 		Name:           name,
 		CvData:         cvData,
@@ -215,50 +239,58 @@ func (s *Service) CreateCV(ctx context.Context, userID string, input CreateCVInp
 		JobDescription: pgtype.Text{Valid: false},
 		MatchScore:     pgtype.Int4{Valid: false},
 		AiSuggestions:  []byte("[]"),
-	})
+	}, SourceManualEdit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cv: %w", err)
+		return nil, err
 	}
 
 	return cvToResponse(cv)
 }
 
-// UpdateCV updates a CV
+// UpdateCV updates a CV and records the result as its next revision
 func (s *Service) UpdateCV(ctx context.Context, userID, cvID string, input UpdateCVInput) (*CVResponse, error) {
 	uuid, err := parseUUID(cvID)
 	if err != nil {
 		return nil, ErrNotFound
 	}
 
-	// Build update params - fields left as zero value (Valid: false) will preserve existing values via COALESCE
-	params := db.UpdateCVParams{
-		ID:     uuid,
-		UserID: userID,
-		// Name, CvData, TemplateID left as zero values - SQL COALESCE will preserve existing
+	if input.Name == nil && input.CVData == nil && input.TemplateID == nil {
+		return s.GetCV(ctx, userID, cvID)
 	}
 
+	// Fields left as zero value (Valid: false) preserve existing values via COALESCE
+	var params db.UpdateCVParams
+
 	if input.Name != nil {
 		params.Name = pgtype.Text{String: *input.Name, Valid: true}
 	}
 
 	if input.CVData != nil {
-		cvData, err := json.Marshal(input.CVData)
-		if err != nil {
+		if params.CvData, err = json.Marshal(input.CVData); err != nil {
 			return nil, fmt.Errorf("%w: failed to marshal cv data", ErrInvalidData)
 		}
-		params.CvData = cvData
 	}
 
 	if input.TemplateID != nil {
+		if !themes.Valid(*input.TemplateID) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTemplate, *input.TemplateID)
+		}
 		params.TemplateID = pgtype.Text{String: *input.TemplateID, Valid: true}
 	}
 
-	cv, err := s.queries.UpdateCV(ctx, params)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
+	cv, err := s.updateWithRevision(ctx, userID, uuid, SourceManualEdit, pgtype.Int4{}, func(current db.GeneratedCv) (db.UpdateCVParams, error) {
+		// Edited content no longer has the stored keyword score
+		if params.CvData != nil {
+			score, err := keywordScore(current.JobDescription, params.CvData)
+			if err != nil {
+				return params, err
+			}
+			params.KeywordScore = score
 		}
-		return nil, fmt.Errorf("failed to update cv: %w", err)
+		return params, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return cvToResponse(cv)
@@ -314,20 +346,23 @@ func (s *Service) DuplicateCV(ctx context.Context, userID, cvID string) (*CVResp
 	}
 
 	// Create a duplicate
-	cv, err := s.queries.CreateCV(ctx, db.CreateCVParams{
-		UserID:         userID,
-		Name:           original.Name + " (Copy)",
-		CvData:         original.CvData,
-		TemplateID:     original.TemplateID,
-		JobUrl:         original.JobUrl,
-		JobTitle:       original.JobTitle,
-		CompanyName:    original.CompanyName,
-		JobDescription: original.JobDescription,
-		MatchScore:     pgtype.Int4{Valid: false}, // Reset match score for copy
-		AiSuggestions:  []byte("[]"),              // Reset AI suggestions for copy
-	})
+	cv, err := s.CreateWithRevision(ctx, db.CreateCVParams{
+		UserID:          userID,
+		Name:            original.Name + " (Copy)",
+		CvData:          original.CvData,
+		TemplateID:      original.TemplateID,
+		JobID:           original.JobID,
+		JobUrl:          original.JobUrl,
+		JobTitle:        original.JobTitle,
+		CompanyName:     original.CompanyName,
+		JobDescription:  original.JobDescription,
+		JobRequirements: original.JobRequirements,
+		KeywordScore:    original.KeywordScore,
+		MatchScore:      pgtype.Int4{Valid: false}, // Reset match score for copy
+		AiSuggestions:   []byte("[]"),              // Reset AI suggestions for copy
+	}, SourceManualEdit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to duplicate cv: %w", err)
+		return nil, err
 	}
 
 	return cvToResponse(cv)
@@ -349,6 +384,7 @@ func cvToResponse(cv db.GeneratedCv) (*CVResponse, error) {
 		Name:           cv.Name,
 		CVData:         &cvData,
 		TemplateID:     textToString(cv.TemplateID),
+		JobID:          uuidToString(cv.JobID),
 		JobURL:         textToString(cv.JobUrl),
 		JobTitle:       textToString(cv.JobTitle),
 		CompanyName:    textToString(cv.CompanyName),
@@ -361,6 +397,10 @@ func cvToResponse(cv db.GeneratedCv) (*CVResponse, error) {
 		score := int(cv.MatchScore.Int32)
 		resp.MatchScore = &score
 	}
+	if cv.KeywordScore.Valid {
+		score := int(cv.KeywordScore.Int32)
+		resp.KeywordScore = &score
+	}
 
 	// Parse AI suggestions if available
 	if len(cv.AiSuggestions) > 0 && string(cv.AiSuggestions) != "[]" {
@@ -373,6 +413,13 @@ func cvToResponse(cv db.GeneratedCv) (*CVResponse, error) {
 		}
 	}
 
+	if len(cv.JobRequirements) > 0 {
+		var requirements models.JobRequirements
+		if err := json.Unmarshal(cv.JobRequirements, &requirements); err == nil {
+			resp.JobRequirements = &requirements
+		}
+	}
+
 	return resp, nil
 }
 
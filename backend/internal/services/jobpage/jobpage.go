@@ -0,0 +1,523 @@
+// Package jobpage reads job postings from the pages they are published on, so
+// users can import a job by its URL instead of pasting its description
+package jobpage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	// ErrInvalidURL is returned for a job URL that is not an http(s) URL
+	ErrInvalidURL = errors.New("invalid job URL")
+	// ErrUnavailable is returned when the job page cannot be fetched
+	ErrUnavailable = errors.New("job page unavailable")
+	// ErrNoPosting is returned when no job posting can be found on the page
+	ErrNoPosting = errors.New("no job posting found on page")
+)
+
+// Request represents a request to read a job posting from its page
+type Request struct {
+	URL string `json:"url"`
+}
+
+// Posting is a job posting read from its page, for the user to confirm
+// before generating from it
+type Posting struct {
+	Title       string `json:"title"`
+	CompanyName string `json:"company_name"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	JobURL      string `json:"job_url"`
+}
+
+// Fetcher fetches job pages and reads the posting from them
+type Fetcher struct {
+	client *http.Client
+}
+
+// NewFetcher creates a job page fetcher. If client is nil a client that only
+// connects to public addresses is used.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = newClient()
+	}
+	return &Fetcher{client: client}
+}
+
+const (
+	pageTimeout = 15 * time.Second
+	// maxPageBytes caps how much of a job page is read
+	maxPageBytes = 2 << 20
+)
+
+// errPrivateAddress is returned when a job URL resolves to an address that is
+// not on the public internet, so users cannot make the server probe its own network
+var errPrivateAddress = errors.New("job page is not on a public address")
+
+// newClient returns the default HTTP client for fetching job pages. It only
+// connects to public addresses, including when following redirects.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicIP(addrPort.Addr()) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   pageTimeout,
+		Transport: transport,
+	}
+}
+
+// nonPublicPrefixes are the special-purpose address ranges of the IANA
+// registries that job pages are never fetched from. IPv6 ranges that embed an
+// IPv4 address (NAT64, 6to4, Teredo) are denied as a whole, since the address
+// they lead to is not checked.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link local, including cloud metadata
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+
+	netip.MustParsePrefix("::/96"),          // unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("::ffff:0:0/96"),  // IPv4-mapped, when not unmapped
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("100::/64"),       // discard-only
+	netip.MustParsePrefix("2001::/23"),      // IETF protocol assignments, including Teredo
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+// isPublicIP reports whether ip is outside every non-public range. IPv4-mapped
+// IPv6 addresses are checked as the IPv4 address they map.
+func isPublicIP(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	// A zone would keep the address out of every prefix
+	ip = ip.WithZone("").Unmap()
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fetch fetches a job posting page and extracts the posting from it.
+// A schema.org JobPosting in the page's JSON-LD is used when present;
+// otherwise the description is the main text of the page.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Posting, error) {
+	pageURL, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (pageURL.Scheme != "http" && pageURL.Scheme != "https") || pageURL.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidURL, rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; cv-gen job importer)")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: page returned status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: page is %q, not HTML", ErrNoPosting, contentType)
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, maxPageBytes), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	doc, err := html.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	posting := extractPosting(doc)
+	if posting.Description == "" {
+		return nil, ErrNoPosting
+	}
+	posting.JobURL = pageURL.String()
+
+	return posting, nil
+}
+
+// extractPosting reads a job posting from a parsed HTML page
+func extractPosting(doc *html.Node) *Posting {
+	page := scanPage(doc)
+
+	for _, data := range page.jsonLD {
+		if posting := postingFromJSONLD(data); posting != nil && posting.Description != "" {
+			return posting
+		}
+	}
+
+	posting := &Posting{
+		Title:       firstNonEmpty(page.meta["og:title"], page.h1, page.title),
+		CompanyName: page.meta["og:site_name"],
+	}
+	if main := mainContent(doc); main != nil {
+		posting.Description = htmlText(main)
+	}
+	return posting
+}
+
+// pageInfo holds what scanPage finds in the head and body of a page
+type pageInfo struct {
+	title  string
+	h1     string
+	meta   map[string]string
+	jsonLD []string
+}
+
+func scanPage(doc *html.Node) *pageInfo {
+	page := &pageInfo{meta: map[string]string{}}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Title:
+				if page.title == "" {
+					page.title = htmlText(n)
+				}
+			case atom.H1:
+				if page.h1 == "" {
+					page.h1 = htmlText(n)
+				}
+			case atom.Meta:
+				key := firstNonEmpty(attr(n, "property"), attr(n, "name"))
+				if key != "" {
+					page.meta[strings.ToLower(key)] = strings.TrimSpace(attr(n, "content"))
+				}
+			case atom.Script:
+				if strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json") && n.FirstChild != nil {
+					page.jsonLD = append(page.jsonLD, n.FirstChild.Data)
+				}
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	return page
+}
+
+// jsonLDPosting is the part of a schema.org JobPosting that is read
+type jsonLDPosting struct {
+	Type               interface{}     `json:"@type"`
+	Title              string          `json:"title"`
+	Description        string          `json:"description"`
+	HiringOrganization json.RawMessage `json:"hiringOrganization"`
+	JobLocation        json.RawMessage `json:"jobLocation"`
+	JobLocationType    string          `json:"jobLocationType"`
+}
+
+// postingFromJSONLD returns the first JobPosting in a JSON-LD block, which
+// may hold a single node, a list of nodes or an @graph of nodes
+func postingFromJSONLD(data string) *Posting {
+	var nodes []json.RawMessage
+	trimmed := strings.TrimSpace(data)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &nodes); err != nil {
+			return nil
+		}
+	} else {
+		var graph struct {
+			Graph []json.RawMessage `json:"@graph"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &graph); err != nil {
+			return nil
+		}
+		nodes = append(graph.Graph, json.RawMessage(trimmed))
+	}
+
+	for _, node := range nodes {
+		var ld jsonLDPosting
+		if err := json.Unmarshal(node, &ld); err != nil || !hasType(ld.Type, "JobPosting") {
+			continue
+		}
+
+		posting := &Posting{
+			Title:       strings.TrimSpace(html.UnescapeString(ld.Title)),
+			CompanyName: organizationName(ld.HiringOrganization),
+			Location:    jobLocation(ld.JobLocation),
+			Description: fragmentText(ld.Description),
+		}
+		if strings.EqualFold(ld.JobLocationType, "TELECOMMUTE") {
+			posting.Location = strings.Join(appendNonEmpty([]string{"Remote"}, posting.Location), "; ")
+		}
+		return posting
+	}
+	return nil
+}
+
+// hasType reports whether a JSON-LD @type, a string or a list of strings, includes name
+func hasType(value interface{}, name string) bool {
+	switch t := value.(type) {
+	case string:
+		return t == name
+	case []interface{}:
+		for _, element := range t {
+			if element == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// organizationName reads a hiringOrganization, either an Organization or its name
+func organizationName(data json.RawMessage) string {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return strings.TrimSpace(name)
+	}
+	var organization struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &organization); err == nil {
+		return strings.TrimSpace(organization.Name)
+	}
+	return ""
+}
+
+// jobLocation reads a jobLocation, one Place or a list of them, as "city, region, country; ..."
+func jobLocation(data json.RawMessage) string {
+	type place struct {
+		Address json.RawMessage `json:"address"`
+	}
+	var places []place
+	if err := json.Unmarshal(data, &places); err != nil {
+		var single place
+		if err := json.Unmarshal(data, &single); err != nil {
+			return ""
+		}
+		places = []place{single}
+	}
+
+	var locations []string
+	for _, p := range places {
+		var address string
+		if err := json.Unmarshal(p.Address, &address); err == nil {
+			locations = appendNonEmpty(locations, strings.TrimSpace(address))
+			continue
+		}
+		var postal struct {
+			Locality string          `json:"addressLocality"`
+			Region   string          `json:"addressRegion"`
+			Country  json.RawMessage `json:"addressCountry"`
+		}
+		if err := json.Unmarshal(p.Address, &postal); err != nil {
+			continue
+		}
+		parts := appendNonEmpty(nil, strings.TrimSpace(postal.Locality))
+		parts = appendNonEmpty(parts, strings.TrimSpace(postal.Region))
+		parts = appendNonEmpty(parts, organizationName(postal.Country))
+		locations = appendNonEmpty(locations, strings.Join(parts, ", "))
+	}
+	return strings.Join(locations, "; ")
+}
+
+// mainContent returns the element holding the main content of a page: its
+// main element, else its article, else its body
+func mainContent(doc *html.Node) *html.Node {
+	found := map[atom.Atom]*html.Node{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			key := n.DataAtom
+			if attr(n, "role") == "main" {
+				key = atom.Main
+			}
+			if found[key] == nil {
+				found[key] = n
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	for _, a := range []atom.Atom{atom.Main, atom.Article, atom.Body} {
+		if found[a] != nil {
+			return found[a]
+		}
+	}
+	return nil
+}
+
+// skippedElements never hold posting text
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Svg: true, atom.Nav: true, atom.Header: true, atom.Footer: true,
+	atom.Aside: true, atom.Form: true, atom.Button: true, atom.Iframe: true,
+}
+
+// blockElements start a new line of text
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.Li: true, atom.Ul: true, atom.Ol: true, atom.Tr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Table: true, atom.Dt: true, atom.Dd: true,
+}
+
+var sourceBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// htmlText returns the readable text of an element: one line per block, list
+// items prefixed with "- ", whitespace collapsed and at most one blank line
+// between paragraphs
+func htmlText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			// Line breaks in the source are layout, not text
+			b.WriteString(sourceBreaks.Replace(n.Data))
+			return
+		case html.ElementNode:
+			if skippedElements[n.DataAtom] {
+				return
+			}
+			if n.DataAtom == atom.Br {
+				b.WriteString("\n")
+				return
+			}
+			if blockElements[n.DataAtom] {
+				b.WriteString("\n")
+			}
+			if n.DataAtom == atom.Li {
+				b.WriteString("- ")
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			b.WriteString("\n")
+		}
+	}
+	walk(n)
+
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(b.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || line == "-" {
+			blank = len(lines) > 0
+			continue
+		}
+		// Items of a list stay together
+		if blank && !(strings.HasPrefix(line, "- ") && strings.HasPrefix(lines[len(lines)-1], "- ")) {
+			lines = append(lines, "")
+		}
+		blank = false
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fragmentText returns the text of a JSON-LD description, which is usually an
+// HTML fragment and sometimes an escaped one
+func fragmentText(fragment string) string {
+	if strings.Contains(fragment, "&lt;") {
+		fragment = html.UnescapeString(fragment)
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "div",
+		DataAtom: atom.Div,
+	})
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+	return htmlText(root)
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func appendNonEmpty(list []string, value string) []string {
+	if value == "" {
+		return list
+	}
+	return append(list, value)
+}
@@ -0,0 +1,205 @@
+package jobpage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+const jsonLDJobPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Careers | Acme</title>
+  <script type="application/ld+json">
+  {
+    "@context": "https://schema.org",
+    "@graph": [
+      {"@type": "Organization", "name": "Acme"},
+      {
+        "@type": "JobPosting",
+        "title": "Senior Go Engineer",
+        "description": "<p>Build our <b>payments</b> platform.</p><ul><li>5+ years of Go</li><li>PostgreSQL</li></ul>",
+        "hiringOrganization": {"@type": "Organization", "name": "Acme Corp"},
+        "jobLocation": {"@type": "Place", "address": {"addressLocality": "Berlin", "addressCountry": {"name": "Germany"}}},
+        "jobLocationType": "TELECOMMUTE"
+      }
+    ]
+  }
+  </script>
+</head>
+<body><main><p>Something else entirely</p></main></body>
+</html>`
+
+const plainJobPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Backend Developer - Globex</title>
+  <meta property="og:site_name" content="Globex">
+  <style>p { color: red; }</style>
+</head>
+<body>
+  <nav><a href="/">Home</a> <a href="/jobs">Jobs</a></nav>
+  <main>
+    <h1>Backend Developer</h1>
+    <p>We are   looking for a
+       backend developer.</p>
+    <h2>Requirements</h2>
+    <ul><li>Go</li><li>Kubernetes</li></ul>
+    <script>track();</script>
+  </main>
+  <footer>Copyright Globex</footer>
+</body>
+</html>`
+
+func newJobPageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	page := func(contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.Write([]byte(body))
+		}
+	}
+	mux.HandleFunc("/jsonld", page("text/html; charset=utf-8", jsonLDJobPage))
+	mux.HandleFunc("/plain", page("text/html", plainJobPage))
+	mux.HandleFunc("/empty", page("text/html", "<html><body><nav>Menu</nav></body></html>"))
+	mux.HandleFunc("/pdf", page("application/pdf", "%PDF-1.4"))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchJSONLD(t *testing.T) {
+	server := newJobPageServer(t)
+	fetcher := NewFetcher(server.Client())
+
+	posting, err := fetcher.Fetch(context.Background(), server.URL+"/jsonld")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	want := Posting{
+		Title:       "Senior Go Engineer",
+		CompanyName: "Acme Corp",
+		Location:    "Remote; Berlin, Germany",
+		Description: "Build our payments platform.\n\n- 5+ years of Go\n- PostgreSQL",
+		JobURL:      server.URL + "/jsonld",
+	}
+	if *posting != want {
+		t.Errorf("Fetch() = %#v, want %#v", *posting, want)
+	}
+}
+
+func TestFetchMainText(t *testing.T) {
+	server := newJobPageServer(t)
+	fetcher := NewFetcher(server.Client())
+
+	posting, err := fetcher.Fetch(context.Background(), server.URL+"/plain")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if posting.Title != "Backend Developer" {
+		t.Errorf("Title = %q, want the page's h1", posting.Title)
+	}
+	if posting.CompanyName != "Globex" {
+		t.Errorf("CompanyName = %q, want og:site_name", posting.CompanyName)
+	}
+	wantDescription := "Backend Developer\n\nWe are looking for a backend developer.\n\nRequirements\n\n- Go\n- Kubernetes"
+	if posting.Description != wantDescription {
+		t.Errorf("Description = %q, want %q", posting.Description, wantDescription)
+	}
+	for _, unwanted := range []string{"Home", "Copyright", "track()", "color"} {
+		if strings.Contains(posting.Description, unwanted) {
+			t.Errorf("Description contains %q from outside the posting", unwanted)
+		}
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	server := newJobPageServer(t)
+
+	tests := []struct {
+		name string
+		url  string
+		want error
+	}{
+		{"not a URL", "acme.com/jobs/1", ErrInvalidURL},
+		{"unsupported scheme", "file:///etc/passwd", ErrInvalidURL},
+		{"not found", server.URL + "/missing", ErrUnavailable},
+		{"not HTML", server.URL + "/pdf", ErrNoPosting},
+		{"no text", server.URL + "/empty", ErrNoPosting},
+	}
+
+	fetcher := NewFetcher(server.Client())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := fetcher.Fetch(context.Background(), tt.url); !errors.Is(err, tt.want) {
+				t.Errorf("Fetch() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchRefusesPrivateAddresses(t *testing.T) {
+	server := newJobPageServer(t)
+
+	// The default client must not reach the local test server
+	_, err := NewFetcher(nil).Fetch(context.Background(), server.URL+"/plain")
+	if !errors.Is(err, ErrUnavailable) || !errors.Is(err, errPrivateAddress) {
+		t.Errorf("Fetch() error = %v, want %v", err, errPrivateAddress)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"8.8.8.8", true},
+		{"2606:4700::1111", true},
+		{"::ffff:93.184.216.34", true},
+
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"10.1.2.3", false},
+		{"100.64.0.1", false},
+		{"100.100.100.200", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"172.16.0.1", false},
+		{"192.0.0.170", false},
+		{"192.0.2.1", false},
+		{"192.168.1.1", false},
+		{"198.18.0.1", false},
+		{"198.19.255.255", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+
+		{"::", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"::127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"64:ff9b:1::1", false},
+		{"2001::a9fe:a9fe", false},
+		{"2002:a9fe:a9fe::1", false},
+		{"fc00::1", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1%eth0", false},
+		{"ff02::1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicIP(netip.MustParseAddr(tt.ip)); got != tt.want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
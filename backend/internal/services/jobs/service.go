@@ -0,0 +1,248 @@
+// Package jobs provides saved job postings that CVs and cover letters are generated for
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+)
+
+var (
+	// ErrNotFound is returned when a job is not found
+	ErrNotFound = errors.New("job not found")
+	// ErrInvalidData is returned when invalid data is provided
+	ErrInvalidData = errors.New("invalid job data")
+)
+
+// Service provides job management operations
+type Service struct {
+	db      db.TxBeginner
+	queries *db.Queries
+}
+
+// New creates a new job service
+func New(pool db.TxBeginner, queries *db.Queries) *Service {
+	return &Service{
+		db:      pool,
+		queries: queries,
+	}
+}
+
+// JobResponse represents the API response for job operations
+type JobResponse struct {
+	ID          string `json:"id"`
+	Title       string `json:"title,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+	JobURL      string `json:"job_url,omitempty"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// CreateJobInput represents input for creating a job
+type CreateJobInput struct {
+	Title       string `json:"title,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+	JobURL      string `json:"job_url,omitempty"`
+	Description string `json:"description"`
+}
+
+// UpdateJobInput represents input for updating a job; nil fields are left unchanged
+type UpdateJobInput struct {
+	Title       *string `json:"title,omitempty"`
+	CompanyName *string `json:"company_name,omitempty"`
+	JobURL      *string `json:"job_url,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ListJobs returns all jobs for a user, newest first
+func (s *Service) ListJobs(ctx context.Context, userID string) ([]JobResponse, error) {
+	jobs, err := s.queries.ListJobsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	items := make([]JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		items = append(items, *jobToResponse(job))
+	}
+	return items, nil
+}
+
+// GetJob retrieves a specific job by ID
+func (s *Service) GetJob(ctx context.Context, userID, jobID string) (*JobResponse, error) {
+	uuid, err := parseUUID(jobID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	job, err := s.queries.GetJobByUserAndId(ctx, db.GetJobByUserAndIdParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return jobToResponse(job), nil
+}
+
+// CreateJob saves a new job
+func (s *Service) CreateJob(ctx context.Context, userID string, input CreateJobInput) (*JobResponse, error) {
+	if strings.TrimSpace(input.Description) == "" {
+		return nil, fmt.Errorf("%w: description is required", ErrInvalidData)
+	}
+
+	job, err := s.queries.CreateJob(ctx, db.CreateJobParams{
+		UserID:      userID,
+		Title:       pgtype.Text{String: input.Title, Valid: input.Title != ""},
+		CompanyName: pgtype.Text{String: input.CompanyName, Valid: input.CompanyName != ""},
+		JobUrl:      pgtype.Text{String: input.JobURL, Valid: input.JobURL != ""},
+		Description: input.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return jobToResponse(job), nil
+}
+
+// UpdateJob updates a job. Changing the description drops its cached analyses.
+func (s *Service) UpdateJob(ctx context.Context, userID, jobID string, input UpdateJobInput) (*JobResponse, error) {
+	uuid, err := parseUUID(jobID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	// Fields left as zero value (Valid: false) preserve existing values via COALESCE
+	params := db.UpdateJobParams{
+		ID:     uuid,
+		UserID: userID,
+	}
+	if input.Title != nil {
+		params.Title = pgtype.Text{String: *input.Title, Valid: true}
+	}
+	if input.CompanyName != nil {
+		params.CompanyName = pgtype.Text{String: *input.CompanyName, Valid: true}
+	}
+	if input.JobURL != nil {
+		params.JobUrl = pgtype.Text{String: *input.JobURL, Valid: true}
+	}
+	if input.Description != nil {
+		if strings.TrimSpace(*input.Description) == "" {
+			return nil, fmt.Errorf("%w: description cannot be empty", ErrInvalidData)
+		}
+		params.Description = pgtype.Text{String: *input.Description, Valid: true}
+	}
+
+	// The new description and the removal of analyses of the old one commit together
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	job, err := q.UpdateJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	if input.Description != nil {
+		if err := q.DeleteJobAnalyses(ctx, job.ID); err != nil {
+			return nil, fmt.Errorf("failed to clear job analyses: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit job update: %w", err)
+	}
+
+	return jobToResponse(job), nil
+}
+
+// DeleteJob deletes a job; CVs and cover letters generated for it are kept
+func (s *Service) DeleteJob(ctx context.Context, userID, jobID string) error {
+	uuid, err := parseUUID(jobID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	// First check if job exists and belongs to user
+	_, err = s.queries.GetJobByUserAndId(ctx, db.GetJobByUserAndIdParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	err = s.queries.DeleteJob(ctx, db.DeleteJobParams{
+		ID:     uuid,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func jobToResponse(job db.Job) *JobResponse {
+	return &JobResponse{
+		ID:          uuidToString(job.ID),
+		Title:       textToString(job.Title),
+		CompanyName: textToString(job.CompanyName),
+		JobURL:      textToString(job.JobUrl),
+		Description: job.Description,
+		CreatedAt:   timestampToString(job.CreatedAt),
+		UpdatedAt:   timestampToString(job.UpdatedAt),
+	}
+}
+
+func uuidToString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	b := id.Bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func parseUUID(s string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	err := uuid.Scan(s)
+	return uuid, err
+}
+
+func textToString(t pgtype.Text) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.String
+}
+
+func timestampToString(ts pgtype.Timestamptz) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format(time.RFC3339)
+}
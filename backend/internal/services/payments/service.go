@@ -0,0 +1,181 @@
+// Package payments handles payment provider webhooks that grant paid credits
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidPayload is returned when the webhook body is not a valid event
+	ErrInvalidPayload = errors.New("invalid webhook payload")
+	// ErrUnknownPackage is returned when a checkout references a package that is not configured
+	ErrUnknownPackage = errors.New("unknown credit package")
+	// ErrMissingUser is returned when a checkout does not identify the purchasing user
+	ErrMissingUser = errors.New("checkout has no user reference")
+)
+
+// EventCheckoutCompleted is the only event type that grants credits
+const EventCheckoutCompleted = "checkout.session.completed"
+
+// Status reports what happened to a webhook event
+type Status string
+
+const (
+	// StatusProcessed means credits were granted for the event
+	StatusProcessed Status = "processed"
+	// StatusDuplicate means the event had already been processed
+	StatusDuplicate Status = "duplicate"
+	// StatusIgnored means the event does not grant credits
+	StatusIgnored Status = "ignored"
+)
+
+// Event is the subset of a payment provider webhook event we use
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object CheckoutSession `json:"object"`
+	} `json:"data"`
+}
+
+// CheckoutSession is the object attached to a checkout-completed event
+type CheckoutSession struct {
+	ID                string            `json:"id"`
+	ClientReferenceID string            `json:"client_reference_id"`
+	PaymentStatus     string            `json:"payment_status"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+// Purchase is a completed checkout resolved to a user and credit amount
+type Purchase struct {
+	EventID   string
+	EventType string
+	UserID    string
+	Package   string
+	Credits   int32
+}
+
+// Store records purchases. RecordPurchase must grant the credits and mark the
+// event as processed atomically, returning false if the event was already
+// processed.
+type Store interface {
+	RecordPurchase(ctx context.Context, purchase Purchase) (bool, error)
+}
+
+// Result is the outcome of handling a webhook
+type Result struct {
+	EventID string `json:"event_id"`
+	Status  Status `json:"status"`
+	UserID  string `json:"user_id,omitempty"`
+	Credits int32  `json:"credits,omitempty"`
+}
+
+// Service verifies and applies payment webhooks
+type Service struct {
+	secret    string
+	tolerance time.Duration
+	packages  map[string]int32
+	store     Store
+	now       func() time.Time
+}
+
+// New creates a new payments service. packages maps package names (from the
+// checkout metadata) to the number of credits they grant.
+func New(secret string, tolerance time.Duration, packages map[string]int32, store Store) *Service {
+	return &Service{
+		secret:    secret,
+		tolerance: tolerance,
+		packages:  packages,
+		store:     store,
+		now:       time.Now,
+	}
+}
+
+// HandleWebhook verifies the signature of a webhook payload and grants the
+// purchased credits for checkout-completed events. Redelivered events are
+// reported as duplicates without granting credits again.
+func (s *Service) HandleWebhook(ctx context.Context, payload []byte, signature string) (*Result, error) {
+	if err := VerifySignature(payload, signature, s.secret, s.tolerance, s.now()); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return nil, fmt.Errorf("%w: missing event id or type", ErrInvalidPayload)
+	}
+
+	result := &Result{EventID: event.ID, Status: StatusIgnored}
+
+	session := event.Data.Object
+	if event.Type != EventCheckoutCompleted {
+		return result, nil
+	}
+	// Asynchronous payment methods complete the checkout before the money arrives
+	if session.PaymentStatus != "" && session.PaymentStatus != "paid" {
+		return result, nil
+	}
+
+	userID := session.ClientReferenceID
+	if userID == "" {
+		userID = session.Metadata["user_id"]
+	}
+	if userID == "" {
+		return nil, ErrMissingUser
+	}
+
+	packageName := session.Metadata["package"]
+	amount, ok := s.packages[packageName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPackage, packageName)
+	}
+
+	recorded, err := s.store.RecordPurchase(ctx, Purchase{
+		EventID:   event.ID,
+		EventType: event.Type,
+		UserID:    userID,
+		Package:   packageName,
+		Credits:   amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record purchase: %w", err)
+	}
+
+	result.UserID = userID
+	result.Credits = amount
+	result.Status = StatusProcessed
+	if !recorded {
+		result.Status = StatusDuplicate
+	}
+	return result, nil
+}
+
+// ParsePackages parses a package list of the form "starter:10,pro:50" into a
+// map of package name to credits
+func ParsePackages(spec string) (map[string]int32, error) {
+	packages := make(map[string]int32)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid credit package %q: expected name:credits", entry)
+		}
+		credits, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil || credits <= 0 {
+			return nil, fmt.Errorf("invalid credit amount for package %q", name)
+		}
+		packages[strings.TrimSpace(name)] = int32(credits)
+	}
+	return packages, nil
+}
@@ -0,0 +1,131 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// memoryStore is an in-memory Store that grants each event at most once
+type memoryStore struct {
+	processed map[string]bool
+	credits   map[string]int32
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{processed: map[string]bool{}, credits: map[string]int32{}}
+}
+
+func (m *memoryStore) RecordPurchase(ctx context.Context, purchase Purchase) (bool, error) {
+	if m.processed[purchase.EventID] {
+		return false, nil
+	}
+	m.processed[purchase.EventID] = true
+	m.credits[purchase.UserID] += purchase.Credits
+	return true, nil
+}
+
+func checkoutEvent(id, eventType, userID, pkg string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"id": %q,
+		"type": %q,
+		"data": {"object": {"id": "cs_1", "client_reference_id": %q, "payment_status": "paid", "metadata": {"package": %q}}}
+	}`, id, eventType, userID, pkg))
+}
+
+func newTestService(store Store) (*Service, time.Time) {
+	now := time.Unix(1700000000, 0)
+	svc := New(testSecret, 5*time.Minute, map[string]int32{"starter": 10, "pro": 100}, store)
+	svc.now = func() time.Time { return now }
+	return svc, now
+}
+
+func TestHandleWebhookGrantsCreditsOnce(t *testing.T) {
+	store := newMemoryStore()
+	svc, now := newTestService(store)
+
+	payload := checkoutEvent("evt_1", EventCheckoutCompleted, "user_1", "pro")
+	signature := SignPayload(payload, testSecret, now)
+
+	result, err := svc.HandleWebhook(context.Background(), payload, signature)
+	if err != nil {
+		t.Fatalf("HandleWebhook returned error: %v", err)
+	}
+	if result.Status != StatusProcessed || result.Credits != 100 || result.UserID != "user_1" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// Providers redeliver events; the second delivery must not grant again
+	result, err = svc.HandleWebhook(context.Background(), payload, signature)
+	if err != nil {
+		t.Fatalf("redelivery returned error: %v", err)
+	}
+	if result.Status != StatusDuplicate {
+		t.Errorf("expected duplicate status, got %s", result.Status)
+	}
+	if store.credits["user_1"] != 100 {
+		t.Errorf("expected 100 credits granted once, got %d", store.credits["user_1"])
+	}
+}
+
+func TestHandleWebhookRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		secret  string
+		wantErr error
+	}{
+		{"bad signature", checkoutEvent("evt_1", EventCheckoutCompleted, "user_1", "pro"), "whsec_other", ErrInvalidSignature},
+		{"unknown package", checkoutEvent("evt_1", EventCheckoutCompleted, "user_1", "enterprise"), testSecret, ErrUnknownPackage},
+		{"missing user", checkoutEvent("evt_1", EventCheckoutCompleted, "", "pro"), testSecret, ErrMissingUser},
+		{"not json", []byte("not json"), testSecret, ErrInvalidPayload},
+		{"missing event id", checkoutEvent("", EventCheckoutCompleted, "user_1", "pro"), testSecret, ErrInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			svc, now := newTestService(store)
+
+			_, err := svc.HandleWebhook(context.Background(), tt.payload, SignPayload(tt.payload, tt.secret, now))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(store.processed) != 0 {
+				t.Error("expected no purchase to be recorded")
+			}
+		})
+	}
+}
+
+func TestHandleWebhookIgnoresOtherEvents(t *testing.T) {
+	store := newMemoryStore()
+	svc, now := newTestService(store)
+
+	payload := checkoutEvent("evt_1", "invoice.paid", "user_1", "pro")
+	result, err := svc.HandleWebhook(context.Background(), payload, SignPayload(payload, testSecret, now))
+	if err != nil {
+		t.Fatalf("HandleWebhook returned error: %v", err)
+	}
+	if result.Status != StatusIgnored || len(store.processed) != 0 {
+		t.Errorf("expected event to be ignored, got %+v", result)
+	}
+}
+
+func TestParsePackages(t *testing.T) {
+	packages, err := ParsePackages("starter:10, pro:100")
+	if err != nil {
+		t.Fatalf("ParsePackages returned error: %v", err)
+	}
+	if packages["starter"] != 10 || packages["pro"] != 100 {
+		t.Errorf("unexpected packages %v", packages)
+	}
+
+	for _, spec := range []string{"starter", "starter:abc", "starter:0"} {
+		if _, err := ParsePackages(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
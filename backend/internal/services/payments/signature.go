@@ -0,0 +1,90 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature header is missing,
+	// malformed, stale or does not match the payload
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// SignatureHeader is the request header carrying the webhook signature
+const SignatureHeader = "Stripe-Signature"
+
+// VerifySignature checks a Stripe-style signature header of the form
+// "t=<unix timestamp>,v1=<hex hmac>[,v1=...]". The signed content is
+// "<timestamp>.<payload>" hashed with HMAC-SHA256 using the webhook secret.
+// Timestamps further than tolerance from now are rejected to limit replays;
+// a zero tolerance disables the check.
+func VerifySignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: missing timestamp or v1 signature", ErrInvalidSignature)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(unix, 0))
+		if age < 0 {
+			age = -age
+		}
+		if age > tolerance {
+			return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+		}
+	}
+
+	expected := computeSignature(payload, timestamp, secret)
+	for _, sig := range signatures {
+		decoded, err := hex.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no matching v1 signature", ErrInvalidSignature)
+}
+
+// SignPayload builds a signature header for payload, as the payment provider
+// would. It is used to sign payloads locally in tests and development.
+func SignPayload(payload []byte, secret string, timestamp time.Time) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", t, hex.EncodeToString(computeSignature(payload, t, secret)))
+}
+
+func computeSignature(payload []byte, timestamp, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package payments
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"id":"evt_1"}`)
+	valid := SignPayload(payload, testSecret, now)
+
+	tests := []struct {
+		name    string
+		header  string
+		payload []byte
+		wantErr bool
+	}{
+		{"valid", valid, payload, false},
+		{"valid among rotated secrets", SignPayload(payload, "whsec_old", now) + "," + strings.Split(valid, ",")[1], payload, false},
+		{"tampered payload", valid, []byte(`{"id":"evt_2"}`), true},
+		{"wrong secret", SignPayload(payload, "whsec_other", now), payload, true},
+		{"stale timestamp", SignPayload(payload, testSecret, now.Add(-10*time.Minute)), payload, true},
+		{"missing signature", "t=1700000000", payload, true},
+		{"malformed timestamp", "t=abc,v1=00", payload, true},
+		{"empty header", "", payload, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.payload, tt.header, testSecret, 5*time.Minute, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Fatalf("expected ErrInvalidSignature, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected valid signature, got %v", err)
+			}
+		})
+	}
+}
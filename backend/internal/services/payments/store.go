@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/services/credits"
+)
+
+// dbStore records purchases in PostgreSQL
+type dbStore struct {
+	db      db.TxBeginner
+	queries *db.Queries
+	credits *credits.Service
+}
+
+// NewStore creates a Store that records the processed event and grants the
+// credits in a single transaction
+func NewStore(pool db.TxBeginner, queries *db.Queries, creditsService *credits.Service) Store {
+	return &dbStore{
+		db:      pool,
+		queries: queries,
+		credits: creditsService,
+	}
+}
+
+// RecordPurchase implements Store
+func (s *dbStore) RecordPurchase(ctx context.Context, purchase Purchase) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	// The event ID is the primary key, so a redelivered event inserts nothing
+	inserted, err := q.CreatePaymentEvent(ctx, db.CreatePaymentEventParams{
+		EventID:   purchase.EventID,
+		EventType: purchase.EventType,
+		UserID:    purchase.UserID,
+		Package:   purchase.Package,
+		Credits:   purchase.Credits,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record payment event: %w", err)
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+
+	if _, err := s.credits.GrantTx(ctx, q, purchase.UserID, purchase.Credits, credits.ReasonPurchase); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,152 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/models"
+)
+
+// ErrVersionNotFound is returned when a profile version is not found
+var ErrVersionNotFound = errors.New("profile version not found")
+
+// VersionListItem represents a profile version in list responses (without resume_data)
+type VersionListItem struct {
+	Version         int32    `json:"version"`
+	ChangedSections []string `json:"changed_sections"`
+	RestoredFrom    *int32   `json:"restored_from,omitempty"`
+	CreatedAt       string   `json:"created_at"`
+}
+
+// VersionResponse represents a single profile version with its content
+type VersionResponse struct {
+	VersionListItem
+	ResumeData *models.JSONResume `json:"resume_data"`
+}
+
+// VersionsResponse lists the versions of a profile, newest first
+type VersionsResponse struct {
+	Versions []VersionListItem `json:"versions"`
+}
+
+// SectionChange holds both sides of a section that differs between versions.
+// A side is null when the section is empty in that version.
+type SectionChange struct {
+	Section string          `json:"section"`
+	Before  json.RawMessage `json:"before"`
+	After   json.RawMessage `json:"after"`
+}
+
+// VersionDiff lists the sections that differ between two profile versions
+type VersionDiff struct {
+	From    int32           `json:"from"`
+	To      int32           `json:"to"`
+	Changes []SectionChange `json:"changes"`
+}
+
+// ListVersions returns the version history of the user's profile
+func (s *Service) ListVersions(ctx context.Context, userID string) (*VersionsResponse, error) {
+	versions, err := s.queries.ListMasterProfileVersions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile versions: %w", err)
+	}
+
+	items := make([]VersionListItem, 0, len(versions))
+	for _, v := range versions {
+		items = append(items, versionListItem(v.Version, v.ChangedSections, v.RestoredFrom, v.CreatedAt))
+	}
+
+	return &VersionsResponse{Versions: items}, nil
+}
+
+// GetVersion retrieves a single version of the user's profile
+func (s *Service) GetVersion(ctx context.Context, userID string, version int32) (*VersionResponse, error) {
+	v, resume, err := s.getVersion(ctx, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionResponse{
+		VersionListItem: versionListItem(v.Version, v.ChangedSections, v.RestoredFrom, v.CreatedAt),
+		ResumeData:      resume,
+	}, nil
+}
+
+// DiffVersions compares two versions of the user's profile section by section
+func (s *Service) DiffVersions(ctx context.Context, userID string, from, to int32) (*VersionDiff, error) {
+	_, before, err := s.getVersion(ctx, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	_, after, err := s.getVersion(ctx, userID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeSections, afterSections := before.SectionsJSON(), after.SectionsJSON()
+
+	changes := []SectionChange{}
+	for _, section := range models.ChangedSections(before, after) {
+		changes = append(changes, SectionChange{
+			Section: section,
+			Before:  beforeSections[section],
+			After:   afterSections[section],
+		})
+	}
+
+	return &VersionDiff{From: from, To: to, Changes: changes}, nil
+}
+
+// RestoreVersion makes an earlier version the user's current profile. The
+// restore is recorded as a new version, so it can itself be undone.
+func (s *Service) RestoreVersion(ctx context.Context, userID string, version int32) (*ProfileResponse, error) {
+	v, resume, err := s.getVersion(ctx, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveProfile(ctx, userID, resume, pgtype.Int4{Int32: v.Version, Valid: true})
+}
+
+func (s *Service) getVersion(ctx context.Context, userID string, version int32) (db.MasterProfileVersion, *models.JSONResume, error) {
+	v, err := s.queries.GetMasterProfileVersion(ctx, db.GetMasterProfileVersionParams{
+		UserID:  userID,
+		Version: version,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return v, nil, ErrVersionNotFound
+		}
+		return v, nil, fmt.Errorf("failed to get profile version: %w", err)
+	}
+
+	var resume models.JSONResume
+	if len(v.ResumeData) > 0 {
+		if err := json.Unmarshal(v.ResumeData, &resume); err != nil {
+			return v, nil, fmt.Errorf("failed to parse resume data: %w", err)
+		}
+	}
+	return v, &resume, nil
+}
+
+func versionListItem(version int32, changedSections []string, restoredFrom pgtype.Int4, createdAt pgtype.Timestamptz) VersionListItem {
+	item := VersionListItem{
+		Version:         version,
+		ChangedSections: changedSections,
+		CreatedAt:       timestampToString(createdAt),
+	}
+	if item.ChangedSections == nil {
+		item.ChangedSections = []string{}
+	}
+	if restoredFrom.Valid {
+		from := restoredFrom.Int32
+		item.RestoredFrom = &from
+	}
+	return item
+}
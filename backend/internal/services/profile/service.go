@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"cv-gen/backend/internal/db"
@@ -25,12 +26,14 @@ var (
 
 // Service provides profile management operations
 type Service struct {
+	db      db.TxBeginner
 	queries *db.Queries
 }
 
 // New creates a new profile service
-func New(queries *db.Queries) *Service {
+func New(pool db.TxBeginner, queries *db.Queries) *Service {
 	return &Service{
+		db:      pool,
 		queries: queries,
 	}
 }
@@ -80,14 +83,46 @@ func (s *Service) CreateOrUpdateProfile(ctx context.Context, userID string, data
 		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
 	}
 
+	return s.saveProfile(ctx, userID, data, pgtype.Int4{})
+}
+
+// saveProfile stores the profile and appends a version to its history when
+// any section changed, in one transaction. restoredFrom is set when restoring
+// an earlier version. The profile is locked while the changes are found, so
+// concurrent saves are numbered one after the other; a profile that does not
+// exist yet has no row to lock, and the upsert waits for a concurrent first
+// save instead.
+func (s *Service) saveProfile(ctx context.Context, userID string, data *models.JSONResume, restoredFrom pgtype.Int4) (*ProfileResponse, error) {
 	// Marshal the data
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal resume data: %w", err)
 	}
 
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+
+	// Compare against the stored profile to find the changed sections
+	var previous *models.JSONResume
+	existing, err := q.GetMasterProfileForUpdate(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to lock profile: %w", err)
+	}
+	if err == nil {
+		previous = &models.JSONResume{}
+		if err := json.Unmarshal(existing.ResumeData, previous); err != nil {
+			return nil, fmt.Errorf("failed to parse resume data: %w", err)
+		}
+	}
+	changed := models.ChangedSections(previous, data)
+
 	// Upsert the profile
-	profile, err := s.queries.UpsertMasterProfile(ctx, db.UpsertMasterProfileParams{
+	profile, err := q.UpsertMasterProfile(ctx, db.UpsertMasterProfileParams{
 		UserID:     userID,
 		ResumeData: jsonData,
 	})
@@ -95,6 +130,22 @@ func (s *Service) CreateOrUpdateProfile(ctx context.Context, userID string, data
 		return nil, fmt.Errorf("failed to save profile: %w", err)
 	}
 
+	if len(changed) > 0 || restoredFrom.Valid {
+		_, err := q.CreateMasterProfileVersion(ctx, db.CreateMasterProfileVersionParams{
+			UserID:          userID,
+			ResumeData:      jsonData,
+			ChangedSections: changed,
+			RestoredFrom:    restoredFrom,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record profile version: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit profile: %w", err)
+	}
+
 	return &ProfileResponse{
 		ID:         uuidToString(profile.ID),
 		UserID:     profile.UserID,
@@ -131,7 +182,8 @@ func (s *Service) UpdateSection(ctx context.Context, userID string, section stri
 	return s.CreateOrUpdateProfile(ctx, userID, existing.ResumeData)
 }
 
-// DeleteProfile deletes a user's profile
+// DeleteProfile deletes a user's profile. Its version history is kept, so a
+// deleted profile can be restored.
 func (s *Service) DeleteProfile(ctx context.Context, userID string) error {
 	return s.queries.DeleteMasterProfile(ctx, userID)
 }
@@ -0,0 +1,288 @@
+// Package share provides public, unauthenticated links to generated CVs
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+
+	"cv-gen/backend/internal/db"
+	"cv-gen/backend/internal/models"
+)
+
+var (
+	// ErrNotFound is returned when a share or the CV it points to does not exist
+	ErrNotFound = errors.New("share not found")
+	// ErrInvalidExpiry is returned when a share would expire in the past
+	ErrInvalidExpiry = errors.New("expiry must be in the future")
+	// ErrInvalidPassword is returned when a share password is too long to hash
+	ErrInvalidPassword = errors.New("password must be at most 72 bytes")
+	// ErrRevoked is returned when opening a share its owner has revoked
+	ErrRevoked = errors.New("share has been revoked")
+	// ErrExpired is returned when opening a share past its expiry
+	ErrExpired = errors.New("share has expired")
+	// ErrPasswordRequired is returned when opening a protected share without a password
+	ErrPasswordRequired = errors.New("password required")
+	// ErrWrongPassword is returned when opening a protected share with the wrong password
+	ErrWrongPassword = errors.New("wrong password")
+)
+
+// tokenBytes is the amount of randomness in a share token; 16 bytes encode to
+// a 22 character URL-safe slug
+const tokenBytes = 16
+
+// Service manages CV share links
+type Service struct {
+	queries *db.Queries
+	now     func() time.Time
+}
+
+// New creates a new share service
+func New(queries *db.Queries) *Service {
+	return &Service{
+		queries: queries,
+		now:     time.Now,
+	}
+}
+
+// CreateShareInput represents input for creating a share link
+type CreateShareInput struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Password  string     `json:"password,omitempty"`
+}
+
+// ShareResponse represents a share link in API responses
+type ShareResponse struct {
+	ID                string `json:"id"`
+	CVID              string `json:"cv_id"`
+	Token             string `json:"token"`
+	Path              string `json:"path"`
+	URL               string `json:"url,omitempty"`
+	PasswordProtected bool   `json:"password_protected"`
+	ExpiresAt         string `json:"expires_at,omitempty"`
+	RevokedAt         string `json:"revoked_at,omitempty"`
+	Active            bool   `json:"active"`
+	ViewCount         int32  `json:"view_count"`
+	LastViewedAt      string `json:"last_viewed_at,omitempty"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// SharedCV is the CV a share link resolves to
+type SharedCV struct {
+	Name       string             `json:"name"`
+	TemplateID string             `json:"template_id"`
+	CVData     *models.JSONResume `json:"cv_data"`
+}
+
+// Create creates a share link for one of the user's CVs
+func (s *Service) Create(ctx context.Context, userID, cvID string, input CreateShareInput) (*ShareResponse, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if _, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: uuid, UserID: userID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	params := db.CreateCVShareParams{
+		CvID:   uuid,
+		UserID: userID,
+	}
+
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(s.now()) {
+			return nil, ErrInvalidExpiry
+		}
+		params.ExpiresAt = pgtype.Timestamptz{Time: *input.ExpiresAt, Valid: true}
+	}
+
+	if input.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+		if err != nil {
+			if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+				return nil, ErrInvalidPassword
+			}
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		params.PasswordHash = pgtype.Text{String: string(hash), Valid: true}
+	}
+
+	params.Token, err = newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := s.queries.CreateCVShare(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share: %w", err)
+	}
+
+	return s.toResponse(share), nil
+}
+
+// List returns the share links of one of the user's CVs, newest first
+func (s *Service) List(ctx context.Context, userID, cvID string) ([]ShareResponse, error) {
+	uuid, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	shares, err := s.queries.ListCVSharesByCV(ctx, db.ListCVSharesByCVParams{CvID: uuid, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+
+	items := make([]ShareResponse, 0, len(shares))
+	for _, share := range shares {
+		items = append(items, *s.toResponse(share))
+	}
+	return items, nil
+}
+
+// Revoke disables a share link. Revoked links stay listed so their view
+// counts remain visible.
+func (s *Service) Revoke(ctx context.Context, userID, cvID, shareID string) (*ShareResponse, error) {
+	cvUUID, err := parseUUID(cvID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	shareUUID, err := parseUUID(shareID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	share, err := s.queries.RevokeCVShare(ctx, db.RevokeCVShareParams{ID: shareUUID, CvID: cvUUID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke share: %w", err)
+	}
+
+	return s.toResponse(share), nil
+}
+
+// Open resolves a share token to its CV, checking revocation, expiry and the
+// password, and counts the view
+func (s *Service) Open(ctx context.Context, token, password string) (*SharedCV, error) {
+	share, err := s.queries.GetCVShareByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get share: %w", err)
+	}
+
+	if err := s.checkAccess(share, password); err != nil {
+		return nil, err
+	}
+
+	cv, err := s.queries.GetCVByUserAndId(ctx, db.GetCVByUserAndIdParams{ID: share.CvID, UserID: share.UserID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cv: %w", err)
+	}
+
+	var cvData models.JSONResume
+	if len(cv.CvData) > 0 {
+		if err := json.Unmarshal(cv.CvData, &cvData); err != nil {
+			return nil, fmt.Errorf("failed to parse cv data: %w", err)
+		}
+	}
+
+	if err := s.queries.RecordCVShareView(ctx, share.ID); err != nil {
+		return nil, fmt.Errorf("failed to record share view: %w", err)
+	}
+
+	return &SharedCV{
+		Name:       cv.Name,
+		TemplateID: cv.TemplateID.String,
+		CVData:     &cvData,
+	}, nil
+}
+
+// checkAccess reports why a share cannot be opened, if it cannot
+func (s *Service) checkAccess(share db.CvShare, password string) error {
+	if share.RevokedAt.Valid {
+		return ErrRevoked
+	}
+	if s.expired(share) {
+		return ErrExpired
+	}
+	if share.PasswordHash.Valid {
+		if password == "" {
+			return ErrPasswordRequired
+		}
+		if bcrypt.CompareHashAndPassword([]byte(share.PasswordHash.String), []byte(password)) != nil {
+			return ErrWrongPassword
+		}
+	}
+	return nil
+}
+
+func (s *Service) toResponse(share db.CvShare) *ShareResponse {
+	return &ShareResponse{
+		ID:                uuidToString(share.ID),
+		CVID:              uuidToString(share.CvID),
+		Token:             share.Token,
+		Path:              "/s/" + share.Token,
+		PasswordProtected: share.PasswordHash.Valid,
+		ExpiresAt:         timestampToString(share.ExpiresAt),
+		RevokedAt:         timestampToString(share.RevokedAt),
+		Active:            !share.RevokedAt.Valid && !s.expired(share),
+		ViewCount:         share.ViewCount,
+		LastViewedAt:      timestampToString(share.LastViewedAt),
+		CreatedAt:         timestampToString(share.CreatedAt),
+	}
+}
+
+// expired reports whether a share is past its expiry
+func (s *Service) expired(share db.CvShare) bool {
+	return share.ExpiresAt.Valid && !s.now().Before(share.ExpiresAt.Time)
+}
+
+// newToken returns a random URL-safe share token
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Helper functions
+
+func uuidToString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	b := id.Bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func parseUUID(s string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	err := uuid.Scan(s)
+	return uuid, err
+}
+
+func timestampToString(ts pgtype.Timestamptz) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format(time.RFC3339)
+}
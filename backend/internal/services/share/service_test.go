@@ -0,0 +1,89 @@
+package share
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+
+	"cv-gen/backend/internal/db"
+)
+
+func newTestService() (*Service, time.Time) {
+	now := time.Unix(1700000000, 0)
+	svc := New(nil)
+	svc.now = func() time.Time { return now }
+	return svc, now
+}
+
+func TestCheckAccess(t *testing.T) {
+	svc, now := newTestService()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	protected := pgtype.Text{String: string(hash), Valid: true}
+
+	tests := []struct {
+		name     string
+		share    db.CvShare
+		password string
+		want     error
+	}{
+		{"open", db.CvShare{}, "", nil},
+		{"open ignores password", db.CvShare{}, "anything", nil},
+		{"not yet expired", db.CvShare{ExpiresAt: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}}, "", nil},
+		{"expired", db.CvShare{ExpiresAt: pgtype.Timestamptz{Time: now, Valid: true}}, "", ErrExpired},
+		{"revoked", db.CvShare{RevokedAt: pgtype.Timestamptz{Time: now, Valid: true}}, "", ErrRevoked},
+		{"revoked wins over password", db.CvShare{RevokedAt: pgtype.Timestamptz{Time: now, Valid: true}, PasswordHash: protected}, "hunter2", ErrRevoked},
+		{"password required", db.CvShare{PasswordHash: protected}, "", ErrPasswordRequired},
+		{"wrong password", db.CvShare{PasswordHash: protected}, "hunter3", ErrWrongPassword},
+		{"right password", db.CvShare{PasswordHash: protected}, "hunter2", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.checkAccess(tt.share, tt.password); !errors.Is(err, tt.want) {
+				t.Errorf("checkAccess() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToResponseActive(t *testing.T) {
+	svc, now := newTestService()
+
+	share := db.CvShare{Token: "abc", ExpiresAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}}
+	resp := svc.toResponse(share)
+	if resp.Active {
+		t.Error("expired share should not be active")
+	}
+	if resp.Path != "/s/abc" {
+		t.Errorf("Path = %q, want /s/abc", resp.Path)
+	}
+
+	if !svc.toResponse(db.CvShare{}).Active {
+		t.Error("share without expiry or revocation should be active")
+	}
+}
+
+func TestNewToken(t *testing.T) {
+	a, err := newToken()
+	if err != nil {
+		t.Fatalf("newToken() error = %v", err)
+	}
+	b, err := newToken()
+	if err != nil {
+		t.Fatalf("newToken() error = %v", err)
+	}
+
+	if len(a) != 22 {
+		t.Errorf("len(token) = %d, want 22", len(a))
+	}
+	if a == b {
+		t.Error("tokens should be unique")
+	}
+}
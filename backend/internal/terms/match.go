@@ -0,0 +1,46 @@
+package terms
+
+// Text is text split into terms once, so many phrases can be matched against it
+type Text struct {
+	keys []string
+}
+
+// NewText prepares text for matching
+func NewText(text string) *Text {
+	parsed := split(text, true)
+	keys := make([]string, len(parsed))
+	for i, t := range parsed {
+		keys[i] = t.Key
+	}
+	return &Text{keys: keys}
+}
+
+// Mentions reports whether the text contains phrase as whole words. Words
+// compare the way the keyword score compares them: ignoring case, with
+// synonyms resolved and ordinary words stemmed, so "Golang" matches "Go" but
+// "Google" does not. A phrase without words is never mentioned.
+func (t *Text) Mentions(phrase string) bool {
+	want := NewText(phrase).keys
+	if len(want) == 0 {
+		return false
+	}
+
+	for start := 0; start+len(want) <= len(t.keys); start++ {
+		found := true
+		for i, key := range want {
+			if t.keys[start+i] != key {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// Mentions reports whether text contains phrase as whole words; see Text.Mentions
+func Mentions(text, phrase string) bool {
+	return NewText(text).Mentions(phrase)
+}
@@ -0,0 +1,250 @@
+// Package terms normalizes the keywords of CVs and job descriptions, so
+// different spellings of one skill compare equal: "Golang" and "Go",
+// "Postgres" and "PostgreSQL", "scaling" and "scalable". The ATS keyword score,
+// requirement coverage and the verification of tailored CVs all match
+// keywords this way.
+package terms
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Term is a normalized keyword with the text it was read from
+type Term struct {
+	// Key is the spelling the term is counted under
+	Key string
+	// Surface is the term as the text spells it, in lower case
+	Surface string
+}
+
+// synonyms maps spellings of a term, including phrases of up to three words,
+// to the spelling it is counted under
+var synonyms = map[string]string{
+	"golang":                      "go",
+	"postgres":                    "postgresql",
+	"psql":                        "postgresql",
+	"k8s":                         "kubernetes",
+	"js":                          "javascript",
+	"ecmascript":                  "javascript",
+	"ts":                          "typescript",
+	"py":                          "python",
+	"reactjs":                     "react",
+	"react.js":                    "react",
+	"node":                        "nodejs",
+	"node.js":                     "nodejs",
+	"vuejs":                       "vue",
+	"vue.js":                      "vue",
+	"nextjs":                      "next.js",
+	"mongo":                       "mongodb",
+	"elastic search":              "elasticsearch",
+	"dotnet":                      ".net",
+	"csharp":                      "c#",
+	"cpp":                         "c++",
+	"mssql":                       "sql server",
+	"sql server":                  "sql server",
+	"amazon web services":         "aws",
+	"google cloud platform":       "gcp",
+	"google cloud":                "gcp",
+	"microsoft azure":             "azure",
+	"machine learning":            "ml",
+	"artificial intelligence":     "ai",
+	"natural language processing": "nlp",
+	"continuous integration":      "ci",
+	"continuous delivery":         "cd",
+	"continuous deployment":       "cd",
+	"restful":                     "rest",
+	"apis":                        "api",
+	"front end":                   "frontend",
+	"back end":                    "backend",
+	"full stack":                  "fullstack",
+	"micro services":              "microservices",
+	"micro service":               "microservices",
+	"microservice":                "microservices",
+	"event driven":                "event-driven",
+}
+
+// vocabulary holds the technical terms that weigh more than ordinary words.
+// Terms in it are never stemmed.
+var vocabulary = map[string]bool{}
+
+func init() {
+	for spelling, key := range synonyms {
+		vocabulary[key] = true
+		if !strings.Contains(spelling, " ") {
+			vocabulary[spelling] = true
+		}
+	}
+	for _, key := range []string{
+		"java", "kotlin", "swift", "rust", "ruby", "php", "scala", "c", "r", "sql",
+		"mysql", "redis", "kafka", "rabbitmq", "docker", "terraform", "ansible",
+		"linux", "git", "graphql", "grpc", "html", "css", "angular", "django",
+		"flask", "spring", "rails", "laravel", "pandas", "spark", "hadoop",
+		"airflow", "snowflake", "tableau", "figma", "jira", "agile", "scrum",
+		"devops", "sre", "saas", "tensorflow", "pytorch",
+	} {
+		vocabulary[key] = true
+	}
+}
+
+// stopwords are left out of the terms: common English words and the words
+// every job posting uses
+var stopwords = wordSet(`
+	a about above across after all also an and any are as at be been being
+	both but by can could do does each either etc for from had has have how
+	i if in into is it its may more most must no not of on or other our out
+	over own per same should so some such than that the their them then there
+	these they this those through to too under up us very via was we well
+	were what when where which while who whom why will with within without
+	would you your yours e.g i.e
+	ability able candidate candidates company day days degree environment
+	excellent experience experienced familiarity familiar good great help
+	ideal including job join knowledge looking new opportunity plus position
+	preferred proven related required requirement requirements
+	responsibilities responsible role skill skills solid strong team teams
+	understanding using use used work working world year years
+`)
+
+// Parse splits text into normalized terms: lower case, synonyms resolved,
+// ordinary words stemmed, and stopwords and numbers left out
+func Parse(text string) []Term {
+	return split(text, false)
+}
+
+// IsTechnical reports whether a term key is a technical term, such as a
+// language or tool
+func IsTechnical(key string) bool {
+	return vocabulary[key]
+}
+
+// split splits text into normalized terms. Stopwords, numbers and single
+// letters are left out unless all is set.
+func split(text string, all bool) []Term {
+	words := words(text)
+
+	var result []Term
+	for i := 0; i < len(words); {
+		// Prefer the longest phrase that has a synonym
+		matched := false
+		for n := 3; n >= 2; n-- {
+			if i+n > len(words) {
+				continue
+			}
+			phrase := strings.Join(words[i:i+n], " ")
+			if key, ok := synonyms[phrase]; ok {
+				result = append(result, Term{Key: key, Surface: phrase})
+				i += n
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		word := words[i]
+		i++
+		if !all && (stopwords[word] || !hasLetter(word)) {
+			continue
+		}
+		if key, ok := synonyms[word]; ok {
+			result = append(result, Term{Key: key, Surface: word})
+			continue
+		}
+		if !all && len(word) == 1 && !vocabulary[word] {
+			continue
+		}
+		result = append(result, Term{Key: stem(word), Surface: word})
+	}
+	return result
+}
+
+// words splits lower-cased text into words. Letters and digits make up words;
+// "+" and "#" end words such as "c++" and "c#", and a "." followed by a
+// letter or digit stays inside words such as "node.js" and ".net".
+func words(text string) []string {
+	runes := []rune(strings.ToLower(text))
+
+	var result []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, string(current))
+			current = current[:0]
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			current = append(current, r)
+		case (r == '+' || r == '#') && len(current) > 0:
+			current = append(current, r)
+		case r == '.' && i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1])):
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return result
+}
+
+// stem reduces an ordinary word to a stem shared by its inflections, so that
+// "scale", "scaling", "scaled", "scales" and "scalable" all count as one term. Terms in the
+// vocabulary and words with symbols are left alone.
+func stem(word string) string {
+	if vocabulary[word] || strings.ContainsAny(word, ".+#") {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ing") && len(word) > 6:
+		word = undouble(word[:len(word)-3])
+	case strings.HasSuffix(word, "able") && len(word) > 6:
+		word = word[:len(word)-4]
+	case strings.HasSuffix(word, "ed") && len(word) > 5:
+		word = undouble(word[:len(word)-2])
+	case strings.HasSuffix(word, "s") && len(word) > 3 &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+
+	// "scale" and the "scal" left of "scaling" meet without the final e
+	if strings.HasSuffix(word, "e") && len(word) > 4 {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+// undouble drops the second of two equal final consonants, as in "plann" from "planned"
+func undouble(word string) string {
+	n := len(word)
+	if n < 2 || word[n-1] != word[n-2] || strings.ContainsRune("aeioulsz", rune(word[n-1])) {
+		return word
+	}
+	return word[:n-1]
+}
+
+// wordSet returns the whitespace-separated words of text as a set
+func wordSet(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(text) {
+		set[word] = true
+	}
+	return set
+}
+
+func hasLetter(word string) bool {
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package terms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Golang, Postgres and k8s", []string{"go", "postgresql", "kubernetes"}},
+		{"Node.js, C++ and C#", []string{"nodejs", "c++", "c#"}},
+		{"Google Cloud Platform or Google Cloud", []string{"gcp", "gcp"}},
+		{"Machine-learning and NLP", []string{"ml", "nlp"}},
+		{"Scaling scaled scales scale scalable", []string{"scal", "scal", "scal", "scal", "scal"}},
+		{"Planned planning plans", []string{"plan", "plan", "plan"}},
+		{"Technologies and technology", []string{"technology", "technology"}},
+		{"Analysis of status", []string{"analysis", "status"}},
+		{"5+ years, e.g. 2020", nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, term := range Parse(tt.text) {
+			got = append(got, term.Key)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text, phrase string
+		want         bool
+	}{
+		{"Built Go services", "go", true},
+		{"Worked at Google", "Go", false},
+		{"Go", "Go", true},
+		{"Node.js and React", "node.js", true},
+		{"Wrote C++ daily", "C++", true},
+		{"Wrote C daily", "C++", false},
+		{"Machine learning pipelines", "machine learning", true},
+		{"Golang", "go", true},
+		{"Moved billing to PostgreSQL", "Postgres", true},
+		{"Ran k8s clusters", "Kubernetes", true},
+		{"Scaling services", "scalable", true},
+		{"Strong team player", "team", true},
+		{"Built APIs", "machine learning", false},
+		{"anything", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := Mentions(tt.text, tt.phrase); got != tt.want {
+			t.Errorf("Mentions(%q, %q) = %v, want %v", tt.text, tt.phrase, got, tt.want)
+		}
+	}
+}
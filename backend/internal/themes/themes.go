@@ -0,0 +1,158 @@
+// Package themes is the registry of CV themes. Each theme is an html/template
+// that produces the same markup as the matching frontend theme component.
+package themes
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"cv-gen/backend/internal/models"
+)
+
+// ErrUnknownTheme is returned when a theme ID is not in the registry
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// DefaultID is the theme new CVs use when none is given
+const DefaultID = "professional"
+
+// Theme describes a registered theme
+type Theme struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// registry lists the available themes in display order. It mirrors THEMES in
+// frontend/src/lib/themes.
+var registry = []Theme{
+	{
+		ID:          "professional",
+		Name:        "Professional",
+		Description: "Clean, traditional layout suitable for corporate roles",
+	},
+	{
+		ID:          "modern",
+		Name:        "Modern",
+		Description: "Contemporary design with visual flair",
+	},
+	{
+		ID:          "minimal",
+		Name:        "Minimal",
+		Description: "Simple, content-focused layout",
+	},
+	{
+		ID:          "academic",
+		Name:        "Academic",
+		Description: "LaTeX-inspired layout for academic and research roles",
+	},
+}
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// templates holds one parsed template set per theme: the shared page shell
+// plus the theme's "theme" definition
+var templates = mustParse()
+
+func mustParse() map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(registry))
+	for _, theme := range registry {
+		tmpl := template.Must(template.New("page.html").Funcs(funcs).ParseFS(templateFS,
+			"templates/page.html", "templates/"+theme.ID+".html"))
+		parsed[theme.ID] = tmpl
+	}
+	return parsed
+}
+
+// List returns the available themes
+func List() []Theme {
+	themes := make([]Theme, len(registry))
+	copy(themes, registry)
+	return themes
+}
+
+// Valid reports whether id names a registered theme
+func Valid(id string) bool {
+	_, ok := templates[id]
+	return ok
+}
+
+// pageData is passed to the page template
+type pageData struct {
+	Title  string
+	Resume *models.JSONResume
+	Basics *models.Basics
+}
+
+// Render writes a complete HTML page showing resume in the theme id
+func Render(w io.Writer, id string, resume *models.JSONResume) error {
+	tmpl, ok := templates[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTheme, id)
+	}
+	if resume == nil {
+		resume = &models.JSONResume{}
+	}
+
+	data := pageData{Title: "Resume", Resume: resume, Basics: resume.Basics}
+	if resume.Basics != nil && resume.Basics.Name != "" {
+		data.Title = resume.Basics.Name
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render theme: %w", err)
+	}
+	return nil
+}
+
+// funcs are the helpers the theme templates share. Date formatting follows
+// the frontend's formatDate and formatDateRange helpers.
+var funcs = template.FuncMap{
+	"formatDate":      formatDate,
+	"formatDateRange": formatDateRange,
+	"join":            strings.Join,
+	"joinNonEmpty":    joinNonEmpty,
+	"notLast": func(i, n int) bool {
+		return i < n-1
+	},
+}
+
+// formatDate renders an ISO 8601 date with layout, e.g. "Jan 2006" or
+// "January 2006". Values that are not full or year-month dates are returned
+// unchanged.
+func formatDate(layout, value string) string {
+	for _, iso := range []string{"2006-01-02", "2006-01"} {
+		if t, err := time.Parse(iso, value); err == nil {
+			return t.Format(layout)
+		}
+	}
+	return value
+}
+
+// formatDateRange renders "start<sep>end" with a missing end date shown as
+// "Present", or nothing when both dates are missing
+func formatDateRange(layout, sep, start, end string) string {
+	if start == "" && end == "" {
+		return ""
+	}
+	endText := "Present"
+	if end != "" {
+		endText = formatDate(layout, end)
+	}
+	return formatDate(layout, start) + sep + endText
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,102 @@
+package themes
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"cv-gen/backend/internal/models"
+)
+
+func testResume() *models.JSONResume {
+	return &models.JSONResume{
+		Basics: &models.Basics{
+			Name:     "Jane <Doe>",
+			Label:    "Backend Engineer",
+			Email:    "jane@example.com",
+			URL:      "javascript:alert(1)",
+			Location: &models.Location{City: "Berlin", CountryCode: "DE"},
+			Profiles: []models.Profile{{Network: "GitHub", Username: "jane", URL: "https://github.com/jane"}},
+		},
+		Work: []models.Work{{
+			Name:       "Acme Corp",
+			Position:   "Senior Backend Engineer",
+			StartDate:  "2021-03",
+			Highlights: []string{"Cut p99 latency by 40%"},
+		}},
+		Education: []models.Education{{
+			Institution: "TU Berlin",
+			StudyType:   "BSc",
+			Area:        "Computer Science",
+			StartDate:   "2013-10-01",
+			EndDate:     "2017-07-01",
+			Courses:     []string{"Compilers"},
+		}},
+		Skills: []models.Skill{{Name: "Backend", Keywords: []string{"Go", "PostgreSQL"}}},
+	}
+}
+
+func TestRenderAllThemes(t *testing.T) {
+	for _, theme := range List() {
+		t.Run(theme.ID, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, theme.ID, testResume()); err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			out := buf.String()
+
+			for _, want := range []string{
+				"<!DOCTYPE html>",
+				"<title>Jane &lt;Doe&gt;</title>",
+				"Senior Backend Engineer",
+				"Cut p99 latency by 40%",
+				"TU Berlin",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q", want)
+				}
+			}
+			if strings.Contains(out, "<Doe>") {
+				t.Error("resume text was not escaped")
+			}
+			if strings.Contains(out, `href="javascript:`) {
+				t.Error("unsafe URL was not sanitized")
+			}
+		})
+	}
+}
+
+func TestRenderDates(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "professional", testResume()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Mar 2021 - Present") {
+		t.Error("professional theme should show short month dates")
+	}
+
+	buf.Reset()
+	if err := Render(&buf, "academic", testResume()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "October 2013 — July 2017") {
+		t.Error("academic theme should show long month dates")
+	}
+}
+
+func TestRenderUnknownTheme(t *testing.T) {
+	err := Render(&bytes.Buffer{}, "fancy", testResume())
+	if !errors.Is(err, ErrUnknownTheme) {
+		t.Errorf("Render() error = %v, want ErrUnknownTheme", err)
+	}
+}
+
+func TestValid(t *testing.T) {
+	if !Valid(DefaultID) {
+		t.Errorf("default theme %q should be valid", DefaultID)
+	}
+	if Valid("") || Valid("Professional") {
+		t.Error("empty and differently cased IDs should be invalid")
+	}
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE credit_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    reason VARCHAR(50) NOT NULL CHECK (reason IN ('cv_generation', 'cover_letter', 'purchase', 'admin_grant', 'refund')),
+    cv_id UUID REFERENCES generated_cvs(id) ON DELETE SET NULL,
+    cover_letter_id UUID REFERENCES cover_letters(id) ON DELETE SET NULL,
+    balance_after INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_transactions_user_id_created_at ON credit_transactions(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS credit_transactions;
+-- +goose StatementEnd
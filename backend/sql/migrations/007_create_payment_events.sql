@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE payment_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    package VARCHAR(50) NOT NULL,
+    credits INTEGER NOT NULL,
+    processed_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_events_user_id ON payment_events(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payment_events;
+-- +goose StatementEnd
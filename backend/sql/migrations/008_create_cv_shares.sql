@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cv_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cv_id UUID NOT NULL REFERENCES generated_cvs(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    password_hash TEXT,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_cv_shares_user_cv ON cv_shares(user_id, cv_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cv_shares;
+-- +goose StatementEnd
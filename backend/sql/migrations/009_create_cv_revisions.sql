@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cv_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cv_id UUID NOT NULL REFERENCES generated_cvs(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    cv_data JSONB NOT NULL DEFAULT '{}',
+    template_id VARCHAR(100),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('manual_edit', 'ai_generation', 'restore')),
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (cv_id, revision)
+);
+
+CREATE INDEX idx_cv_revisions_user_cv ON cv_revisions(user_id, cv_id);
+
+-- Existing CVs start their history at their current content; CVs with a
+-- stored analysis came from AI generation
+INSERT INTO cv_revisions (cv_id, user_id, revision, name, cv_data, template_id, source, created_at)
+SELECT id, user_id, 1, name, cv_data, template_id,
+       CASE WHEN ai_suggestions IS NOT NULL AND ai_suggestions <> '[]'::jsonb THEN 'ai_generation' ELSE 'manual_edit' END,
+       COALESCE(updated_at, NOW())
+FROM generated_cvs;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cv_revisions;
+-- +goose StatementEnd
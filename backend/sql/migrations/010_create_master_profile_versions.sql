@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE master_profile_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    resume_data JSONB NOT NULL DEFAULT '{}',
+    changed_sections TEXT[] NOT NULL DEFAULT '{}',
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (user_id, version)
+);
+
+-- Existing profiles start their history at their current content
+INSERT INTO master_profile_versions (user_id, version, resume_data, changed_sections, created_at)
+SELECT user_id, 1, resume_data,
+       ARRAY(
+           SELECT s FROM jsonb_object_keys(resume_data) AS s
+           WHERE s IN ('basics', 'work', 'volunteer', 'education', 'awards', 'certificates',
+                       'publications', 'skills', 'languages', 'interests', 'references', 'projects')
+       ),
+       COALESCE(updated_at, NOW())
+FROM master_profiles;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS master_profile_versions;
+-- +goose StatementEnd
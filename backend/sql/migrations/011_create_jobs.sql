@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    title VARCHAR(255),
+    company_name VARCHAR(255),
+    job_url TEXT,
+    description TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_jobs_user_id ON jobs(user_id);
+
+-- Analyses are cached per master profile version, so a profile edit makes
+-- the next generation analyze the job again
+CREATE TABLE job_analyses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    profile_version INTEGER NOT NULL,
+    analysis JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (job_id, profile_version)
+);
+
+ALTER TABLE generated_cvs
+ADD COLUMN job_id UUID REFERENCES jobs(id) ON DELETE SET NULL;
+
+ALTER TABLE cover_letters
+ADD COLUMN job_id UUID REFERENCES jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_generated_cvs_job_id ON generated_cvs(job_id);
+CREATE INDEX idx_cover_letters_job_id ON cover_letters(job_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cover_letters DROP COLUMN job_id;
+ALTER TABLE generated_cvs DROP COLUMN job_id;
+DROP TABLE IF EXISTS job_analyses;
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
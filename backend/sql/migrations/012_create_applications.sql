@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Applications keep their own copy of the job details, so deleting the CV
+-- or job they were made from does not erase the record of having applied
+CREATE TABLE applications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    cv_id UUID REFERENCES generated_cvs(id) ON DELETE SET NULL,
+    cover_letter_id UUID REFERENCES cover_letters(id) ON DELETE SET NULL,
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,
+    job_title VARCHAR(255),
+    company_name VARCHAR(255),
+    job_url TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'drafted'
+        CHECK (status IN ('drafted', 'applied', 'screening', 'interview', 'offer', 'rejected', 'withdrawn')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_applications_user_status ON applications(user_id, status);
+
+CREATE TABLE application_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_application_status_changes_application_id ON application_status_changes(application_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS application_status_changes;
+DROP TABLE IF EXISTS applications;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Structured requirements parsed from the job description of a generated CV
+ALTER TABLE generated_cvs
+ADD COLUMN job_requirements JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE generated_cvs
+DROP COLUMN job_requirements;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deterministic keyword match score of a generated CV against its job description
+ALTER TABLE generated_cvs
+ADD COLUMN keyword_score INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE generated_cvs
+DROP COLUMN keyword_score;
+-- +goose StatementEnd
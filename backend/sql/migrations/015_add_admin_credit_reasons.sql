@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Admin revokes and free usage resets are recorded under their own reasons
+ALTER TABLE credit_transactions
+DROP CONSTRAINT credit_transactions_reason_check,
+ADD CONSTRAINT credit_transactions_reason_check
+    CHECK (reason IN ('cv_generation', 'cover_letter', 'purchase', 'admin_grant', 'admin_revoke', 'admin_reset', 'refund'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE credit_transactions SET reason = 'admin_grant' WHERE reason IN ('admin_revoke', 'admin_reset');
+
+ALTER TABLE credit_transactions
+DROP CONSTRAINT credit_transactions_reason_check,
+ADD CONSTRAINT credit_transactions_reason_check
+    CHECK (reason IN ('cv_generation', 'cover_letter', 'purchase', 'admin_grant', 'refund'));
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Refunds reference the debit they return, so the ledger can be reconciled
+ALTER TABLE credit_transactions
+ADD COLUMN refunded_transaction_id UUID REFERENCES credit_transactions(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_transactions DROP COLUMN IF EXISTS refunded_transaction_id;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Analyses are also keyed by a hash of the job description they were made
+-- for, so an analysis that finishes after the description changed is never
+-- served for the new one. Existing analyses are only a cache and are dropped.
+DELETE FROM job_analyses;
+
+ALTER TABLE job_analyses
+ADD COLUMN description_hash VARCHAR(64) NOT NULL,
+DROP CONSTRAINT job_analyses_job_id_profile_version_key,
+ADD CONSTRAINT job_analyses_job_id_profile_version_description_hash_key
+    UNIQUE (job_id, profile_version, description_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM job_analyses;
+
+ALTER TABLE job_analyses
+DROP CONSTRAINT job_analyses_job_id_profile_version_description_hash_key,
+DROP COLUMN description_hash,
+ADD CONSTRAINT job_analyses_job_id_profile_version_key UNIQUE (job_id, profile_version);
+-- +goose StatementEnd
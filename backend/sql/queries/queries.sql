@@ -5,6 +5,22 @@
 -- name: GetMasterProfile :one
 SELECT * FROM master_profiles WHERE user_id = $1 LIMIT 1;
 
+-- name: GetMasterProfileWithVersion :one
+-- Reads the profile with its latest version number in one snapshot
+SELECT mp.*, v.version
+FROM master_profiles mp
+LEFT JOIN LATERAL (
+    SELECT version FROM master_profile_versions
+    WHERE user_id = mp.user_id
+    ORDER BY version DESC
+    LIMIT 1
+) v ON TRUE
+WHERE mp.user_id = $1;
+
+-- name: GetMasterProfileForUpdate :one
+-- Locks the profile while it is saved and its next version is numbered
+SELECT * FROM master_profiles WHERE user_id = $1 FOR UPDATE;
+
 -- name: CreateMasterProfile :one
 INSERT INTO master_profiles (user_id, resume_data)
 VALUES ($1, $2)
@@ -69,6 +85,40 @@ SET paid_credits = paid_credits + $2, updated_at = NOW()
 WHERE user_id = $1
 RETURNING *;
 
+-- name: GetUserCreditsForUpdate :one
+-- Locks the user's credit row until the surrounding transaction ends
+SELECT * FROM user_credits WHERE user_id = $1 FOR UPDATE;
+
+-- name: RefundCredit :one
+-- Returns a reserved credit to the pool (free or paid) it was taken from.
+-- Free usage stays at or above zero in case an admin reset it meanwhile
+UPDATE user_credits
+SET
+    total_generations = total_generations - 1,
+    free_generations_used = CASE
+        WHEN @refund_free::boolean THEN GREATEST(free_generations_used - 1, 0)
+        ELSE free_generations_used
+    END,
+    paid_credits = CASE
+        WHEN @refund_free::boolean THEN paid_credits
+        ELSE paid_credits + 1
+    END,
+    updated_at = NOW()
+WHERE user_id = @user_id
+RETURNING *;
+
+-- name: ResetFreeGenerationsUsed :one
+-- Gives the user their full free allowance again
+UPDATE user_credits
+SET free_generations_used = 0, updated_at = NOW()
+WHERE user_id = $1
+RETURNING *;
+
+-- name: ListTopUsersByGenerations :many
+SELECT * FROM user_credits
+ORDER BY total_generations DESC, user_id
+LIMIT $1;
+
 -- ===================
 -- Generated CVs
 -- ===================
@@ -79,6 +129,10 @@ SELECT * FROM generated_cvs WHERE id = $1 LIMIT 1;
 -- name: GetCVByUserAndId :one
 SELECT * FROM generated_cvs WHERE id = $1 AND user_id = $2 LIMIT 1;
 
+-- name: GetCVForUpdate :one
+-- Locks the CV while it is written and its next revision is numbered
+SELECT * FROM generated_cvs WHERE id = $1 AND user_id = $2 FOR UPDATE;
+
 -- name: ListCVsByUser :many
 SELECT * FROM generated_cvs 
 WHERE user_id = $1 
@@ -96,17 +150,21 @@ SELECT COUNT(*) FROM generated_cvs WHERE user_id = $1;
 -- name: CreateCV :one
 INSERT INTO generated_cvs (
     user_id, name, job_url, job_title, company_name, 
-    job_description, cv_data, match_score, ai_suggestions, template_id
+    job_description, cv_data, match_score, ai_suggestions, template_id, job_id, job_requirements,
+    keyword_score
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
 
 -- name: UpdateCV :one
+-- The keyword score is written with the CV data, NULL included, since it is
+-- computed from it
 UPDATE generated_cvs
 SET 
     name = COALESCE(sqlc.narg('name'), name),
     cv_data = COALESCE(sqlc.narg('cv_data'), cv_data),
     template_id = COALESCE(sqlc.narg('template_id'), template_id),
+    keyword_score = CASE WHEN sqlc.narg('cv_data')::jsonb IS NULL THEN keyword_score ELSE sqlc.narg('keyword_score') END,
     updated_at = NOW()
 WHERE id = $1 AND user_id = $2
 RETURNING *;
@@ -141,8 +199,8 @@ WHERE cv_id = $1
 ORDER BY created_at DESC;
 
 -- name: CreateCoverLetter :one
-INSERT INTO cover_letters (user_id, cv_id, content, job_title, company_name)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO cover_letters (user_id, cv_id, content, job_title, company_name, job_id)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: UpdateCoverLetter :one
@@ -153,3 +211,217 @@ RETURNING *;
 
 -- name: DeleteCoverLetter :exec
 DELETE FROM cover_letters WHERE id = $1 AND user_id = $2;
+
+-- ===================
+-- Credit Transactions
+-- ===================
+
+-- name: CreateCreditTransaction :one
+INSERT INTO credit_transactions (user_id, amount, reason, cv_id, cover_letter_id, balance_after, refunded_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: SetCreditTransactionReference :exec
+-- Links a debit to the CV or cover letter it paid for once that has been saved
+UPDATE credit_transactions
+SET cv_id = $2, cover_letter_id = $3
+WHERE id = $1;
+
+-- name: ListCreditTransactionsByUser :many
+SELECT * FROM credit_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCreditTransactionsByUser :one
+SELECT COUNT(*) FROM credit_transactions WHERE user_id = $1;
+
+-- ===================
+-- Payment Events
+-- ===================
+
+-- name: CreatePaymentEvent :execrows
+-- Records a processed webhook event; affects no rows if it was already processed
+INSERT INTO payment_events (event_id, event_type, user_id, package, credits)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (event_id) DO NOTHING;
+
+-- ===================
+-- CV Shares
+-- ===================
+
+-- name: CreateCVShare :one
+INSERT INTO cv_shares (cv_id, user_id, token, password_hash, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListCVSharesByCV :many
+SELECT * FROM cv_shares
+WHERE cv_id = $1 AND user_id = $2
+ORDER BY created_at DESC;
+
+-- name: GetCVShareByToken :one
+SELECT * FROM cv_shares WHERE token = $1 LIMIT 1;
+
+-- name: RevokeCVShare :one
+-- Revoking an already revoked share keeps its original revocation time
+UPDATE cv_shares
+SET revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1 AND cv_id = $2 AND user_id = $3
+RETURNING *;
+
+-- name: RecordCVShareView :exec
+UPDATE cv_shares
+SET view_count = view_count + 1, last_viewed_at = NOW()
+WHERE id = $1;
+
+-- ===================
+-- CV Revisions
+-- ===================
+
+-- name: CreateCVRevision :one
+-- Snapshots the current name, content and template of a CV as its next revision
+INSERT INTO cv_revisions (cv_id, user_id, revision, name, cv_data, template_id, source, restored_from)
+SELECT g.id, g.user_id,
+       COALESCE((SELECT MAX(r.revision) FROM cv_revisions r WHERE r.cv_id = g.id), 0) + 1,
+       g.name, g.cv_data, g.template_id, sqlc.arg('source')::VARCHAR, sqlc.narg('restored_from')::INTEGER
+FROM generated_cvs g
+WHERE g.id = $1 AND g.user_id = $2
+RETURNING *;
+
+-- name: ListCVRevisions :many
+SELECT * FROM cv_revisions
+WHERE cv_id = $1 AND user_id = $2
+ORDER BY revision DESC;
+
+-- name: GetCVRevision :one
+SELECT * FROM cv_revisions
+WHERE cv_id = $1 AND user_id = $2 AND revision = $3
+LIMIT 1;
+
+-- ===================
+-- Master Profile Versions
+-- ===================
+
+-- name: CreateMasterProfileVersion :one
+INSERT INTO master_profile_versions (user_id, version, resume_data, changed_sections, restored_from)
+VALUES (
+    $1,
+    COALESCE((SELECT MAX(v.version) FROM master_profile_versions v WHERE v.user_id = $1), 0) + 1,
+    $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListMasterProfileVersions :many
+SELECT id, user_id, version, changed_sections, restored_from, created_at
+FROM master_profile_versions
+WHERE user_id = $1
+ORDER BY version DESC;
+
+-- name: GetMasterProfileVersion :one
+SELECT * FROM master_profile_versions
+WHERE user_id = $1 AND version = $2
+LIMIT 1;
+
+-- name: GetLatestMasterProfileVersion :one
+SELECT * FROM master_profile_versions
+WHERE user_id = $1
+ORDER BY version DESC
+LIMIT 1;
+
+-- ===================
+-- Jobs
+-- ===================
+
+-- name: CreateJob :one
+INSERT INTO jobs (user_id, title, company_name, job_url, description)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetJobByUserAndId :one
+SELECT * FROM jobs WHERE id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: ListJobsByUser :many
+SELECT * FROM jobs
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateJob :one
+UPDATE jobs
+SET
+    title = COALESCE(sqlc.narg('title'), title),
+    company_name = COALESCE(sqlc.narg('company_name'), company_name),
+    job_url = COALESCE(sqlc.narg('job_url'), job_url),
+    description = COALESCE(sqlc.narg('description'), description),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteJob :exec
+DELETE FROM jobs WHERE id = $1 AND user_id = $2;
+
+-- name: GetJobAnalysis :one
+SELECT * FROM job_analyses
+WHERE job_id = $1 AND profile_version = $2 AND description_hash = $3
+LIMIT 1;
+
+-- name: UpsertJobAnalysis :one
+INSERT INTO job_analyses (job_id, profile_version, description_hash, analysis)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (job_id, profile_version, description_hash) DO UPDATE SET analysis = EXCLUDED.analysis, created_at = NOW()
+RETURNING *;
+
+-- name: DeleteJobAnalyses :exec
+-- Drops the cached analyses of a job once its description changes
+DELETE FROM job_analyses WHERE job_id = $1;
+
+-- ===================
+-- Applications
+-- ===================
+
+-- name: CreateApplication :one
+INSERT INTO applications (
+    user_id, cv_id, cover_letter_id, job_id, job_title, company_name, job_url, status, notes
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetApplicationByUserAndId :one
+SELECT * FROM applications WHERE id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: GetApplicationForUpdate :one
+-- Locks the application while its status is changed
+SELECT * FROM applications WHERE id = $1 AND user_id = $2 FOR UPDATE;
+
+-- name: ListApplicationsByUser :many
+SELECT * FROM applications
+WHERE user_id = $1 AND (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'))
+ORDER BY updated_at DESC;
+
+-- name: UpdateApplication :one
+UPDATE applications
+SET
+    cover_letter_id = COALESCE(sqlc.narg('cover_letter_id'), cover_letter_id),
+    notes = COALESCE(sqlc.narg('notes'), notes),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: UpdateApplicationStatus :one
+UPDATE applications
+SET status = $3, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteApplication :exec
+DELETE FROM applications WHERE id = $1 AND user_id = $2;
+
+-- name: CreateApplicationStatusChange :one
+INSERT INTO application_status_changes (application_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListApplicationStatusChanges :many
+SELECT * FROM application_status_changes
+WHERE application_id = $1
+ORDER BY changed_at, id;
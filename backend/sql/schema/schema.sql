@@ -44,3 +44,106 @@ CREATE TABLE cover_letters (
     created_at TIMESTAMPTZ DEFAULT NOW(),
     updated_at TIMESTAMPTZ DEFAULT NOW()
 );
+
+CREATE TABLE credit_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    reason VARCHAR(50) NOT NULL CHECK (reason IN ('cv_generation', 'cover_letter', 'purchase', 'admin_grant', 'refund')),
+    cv_id UUID REFERENCES generated_cvs(id) ON DELETE SET NULL,
+    cover_letter_id UUID REFERENCES cover_letters(id) ON DELETE SET NULL,
+    balance_after INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE payment_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    package VARCHAR(50) NOT NULL,
+    credits INTEGER NOT NULL,
+    processed_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE cv_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cv_id UUID NOT NULL REFERENCES generated_cvs(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    password_hash TEXT,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE cv_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cv_id UUID NOT NULL REFERENCES generated_cvs(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    cv_data JSONB NOT NULL DEFAULT '{}',
+    template_id VARCHAR(100),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('manual_edit', 'ai_generation', 'restore')),
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (cv_id, revision)
+);
+
+CREATE TABLE master_profile_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    resume_data JSONB NOT NULL DEFAULT '{}',
+    changed_sections TEXT[] NOT NULL DEFAULT '{}',
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (user_id, version)
+);
+
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    title VARCHAR(255),
+    company_name VARCHAR(255),
+    job_url TEXT,
+    description TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE job_analyses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    profile_version INTEGER NOT NULL,
+    analysis JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (job_id, profile_version)
+);
+
+CREATE TABLE applications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    cv_id UUID REFERENCES generated_cvs(id) ON DELETE SET NULL,
+    cover_letter_id UUID REFERENCES cover_letters(id) ON DELETE SET NULL,
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,
+    job_title VARCHAR(255),
+    company_name VARCHAR(255),
+    job_url TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'drafted'
+        CHECK (status IN ('drafted', 'applied', 'screening', 'interview', 'offer', 'rejected', 'withdrawn')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE application_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ DEFAULT NOW()
+);